@@ -0,0 +1,266 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+// CancelReceiptRequest is the data for the POST to the Pushover
+// Receipts API cancel endpoint. See the Pushover Receipts API
+// documentation for more information on these parameters.
+type CancelReceiptRequest struct {
+	// The base URL for the Pushover Receipts API. The receipt
+	// and cancel endpoint are appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Receipt returned in the MessageResponse of a message
+	// sent with a priority of 2
+	Receipt string
+}
+
+// CancelReceiptResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type CancelReceiptResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// CancelReceiptContext will submit a POST request to the Pushover
+// Receipts API. This function will stop the retries of a
+// notification sent with a priority of 2.
+//
+//	  resp, err := pushover.CancelReceiptContext(context.Background(),
+//	    pushover.CancelReceiptRequest{
+//		     Token:   token,
+//		     Receipt: receipt,
+//	  })
+func CancelReceiptContext(ctx context.Context, request CancelReceiptRequest) (*CancelReceiptResponse, error) {
+	return defaultClient.CancelReceiptContext(ctx, request)
+}
+
+// CancelReceiptContext will submit a POST request to the
+// Pushover Receipts API with the client's settings. This
+// function will stop the retries of a notification sent with
+// a priority of 2.
+//
+//	  resp, err := client.CancelReceiptContext(context.Background(),
+//	    pushover.CancelReceiptRequest{
+//		     Receipt: receipt,
+//	  })
+func (c *Client) CancelReceiptContext(ctx context.Context, request CancelReceiptRequest) (*CancelReceiptResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, receiptsURL, receiptsPath)
+	request.Token = c.token(request.Token)
+
+	formData := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL+"/"+url.PathEscape(request.Receipt)+"/cancel.json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CancelReceiptResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// CancelReceipt will submit a POST request to the Pushover
+// Receipts API. This function will stop the retries of a
+// notification sent with a priority of 2.
+//
+//	  resp, err := pushover.CancelReceipt(pushover.CancelReceiptRequest{
+//		     Token:   token,
+//		     Receipt: receipt,
+//	  })
+func CancelReceipt(request CancelReceiptRequest) (*CancelReceiptResponse, error) {
+	return CancelReceiptContext(context.Background(), request)
+}
+
+// CancelByTagRequest is the data for the POST to the Pushover
+// Receipts API cancel by tag endpoint. See the Pushover Receipts
+// API documentation for more information on these parameters.
+type CancelByTagRequest struct {
+	// The base URL for the Pushover Receipts API. The cancel
+	// by tag endpoint and the tag are appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Tag submitted in the Tags field of one or more
+	// messages sent with a priority of 2
+	Tag string
+}
+
+// CancelByTagResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type CancelByTagResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Number of receipts cancelled
+	Canceled int
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// CancelByTagContext will submit a POST request to the Pushover
+// Receipts API. This function will stop the retries of all
+// notifications sent with a priority of 2 and the given tag.
+//
+//	  resp, err := pushover.CancelByTagContext(context.Background(),
+//	    pushover.CancelByTagRequest{
+//		     Token: token,
+//		     Tag:   tag,
+//	  })
+func CancelByTagContext(ctx context.Context, request CancelByTagRequest) (*CancelByTagResponse, error) {
+	return defaultClient.CancelByTagContext(ctx, request)
+}
+
+// CancelByTagContext will submit a POST request to the
+// Pushover Receipts API with the client's settings. This
+// function will stop the retries of all notifications sent
+// with a priority of 2 and the given tag.
+//
+//	  resp, err := client.CancelByTagContext(context.Background(),
+//	    pushover.CancelByTagRequest{
+//		     Tag: tag,
+//	  })
+func (c *Client) CancelByTagContext(ctx context.Context, request CancelByTagRequest) (*CancelByTagResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, receiptsURL, receiptsPath)
+	request.Token = c.token(request.Token)
+
+	formData := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL+"/cancel_by_tag/"+url.PathEscape(request.Tag)+".json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CancelByTagResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	var ok bool
+
+	// Populate number of cancelled receipts
+	if r.Canceled, ok = api.MapKeyToInt(keyCanceled, a.Result); ok {
+		delete(a.Result, keyCanceled)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// CancelByTag will submit a POST request to the Pushover
+// Receipts API. This function will stop the retries of all
+// notifications sent with a priority of 2 and the given tag.
+//
+//	  resp, err := pushover.CancelByTag(pushover.CancelByTagRequest{
+//		     Token: token,
+//		     Tag:   tag,
+//	  })
+func CancelByTag(request CancelByTagRequest) (*CancelByTagResponse, error) {
+	return CancelByTagContext(context.Background(), request)
+}
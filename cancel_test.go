@@ -0,0 +1,204 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Cancel Valid
+{"status":1,"request":"e460545a-2c1b-4ff6-9ee4-2de1fc5e5ac2"}
+
+Cancel By Tag Valid
+{"status":1,"canceled":3,"request":"0c7c4a7b-8a1b-4ee9-a2c0-a0d2b1e0e3f4"}
+
+Cancel Invalid Receipt
+{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"1ab5ca4a-1f3a-4bd4-9d53-e8b5e1a7b52b"}
+*/
+
+func cancelServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check token
+	value := r.Form["token"]
+	if len(value) == 0 || len(value[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	receipt := strings.TrimSuffix(r.URL.Path, ".json")
+	if strings.Contains(receipt, "/cancel_by_tag/") {
+		receipt = receipt[strings.LastIndex(receipt, "/")+1:]
+		if receipt == "incident" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"status":1,"canceled":3,"request":"%s"}`, id)
+			return
+		}
+	} else {
+		receipt = strings.TrimSuffix(receipt, "/cancel")
+		receipt = receipt[strings.LastIndex(receipt, "/")+1:]
+	}
+
+	switch receipt {
+	case "":
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"%s"}`, id)
+	case "failstatus":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":"abc","request":"%s"}`, id)
+	case "failrequest":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":1337}`)
+	case "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"errors":"invalid"],"status":0,"request":"%s"}`, id)
+	case "failbody":
+		w.Header().Set("Content-Length", "1")
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+	}
+}
+
+func TestPushoverCancelReceipt(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(cancelServerHandler))
+	defer apiServer.Close()
+
+	var request CancelReceiptRequest
+
+	// Default Pushover URL
+	receiptsURL = apiServer.URL
+	r, e := CancelReceiptContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no receipt
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	r, _ = CancelReceipt(request)
+	if r.HTTPStatusCode != http.StatusNotFound || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "receipt not found; may be invalid or expired" || r.ErrorParameters["receipt"] != "not found" {
+		t.Error("Handling of no receipt")
+	}
+
+	// Valid submission
+	request.Receipt = "receipt"
+	r, e = CancelReceipt(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = CancelReceiptContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid API Status in response
+	request.Receipt = "failstatus"
+	_, e = CancelReceipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid API status in response")
+	}
+
+	// Invalid request ID in response
+	request.Receipt = "failrequest"
+	_, e = CancelReceipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid request ID in response")
+	}
+
+	// Invalid json response
+	request.Receipt = "failjson"
+	_, e = CancelReceipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Invalid body
+	request.Receipt = "failbody"
+	_, e = CancelReceipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = CancelReceipt(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverCancelByTag(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(cancelServerHandler))
+	defer apiServer.Close()
+
+	var request CancelByTagRequest
+
+	// Default Pushover URL
+	receiptsURL = apiServer.URL
+	r, e := CancelByTagContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	request.Tag = "incident"
+	r, e = CancelByTag(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.Canceled != 3 || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = CancelByTagContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Tag = "failjson"
+	_, e = CancelByTag(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Invalid body
+	request.Tag = "failbody"
+	_, e = CancelByTag(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = CancelByTag(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
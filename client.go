@@ -0,0 +1,103 @@
+package pushover
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+// defaultClientTimeout is the Timeout of a Client returned by
+// NewClient
+const defaultClientTimeout = 30 * time.Second
+
+// Client holds the settings shared by requests to the Pushover
+// API. Its methods submit the same requests as the package
+// functions of the same name. The package functions use a
+// Client with no settings.
+//
+// A request's PushoverURL overrides the client's BaseURL and a
+// request's Token overrides the client's Token.
+//
+// A Client is safe for use by multiple goroutines. Its fields
+// must not be changed while it is in use.
+type Client struct {
+	// HTTP client used to submit requests
+	//
+	// If nil, http.DefaultClient is used. Share a Client, or
+	// its HTTP client, to reuse connections between requests.
+	HTTPClient *http.Client
+
+	// Base URL of the Pushover REST API, such as
+	// https://api.pushover.net/1
+	//
+	// Leave this empty unless you wish to override the URL.
+	BaseURL string
+
+	// Pushover API token used when a request has no Token
+	Token string
+
+	// User-Agent header sent with each request
+	//
+	// If empty, the Go HTTP client default is sent.
+	UserAgent string
+
+	// Time limit for each request, including reading the
+	// response
+	//
+	// If zero, requests are only limited by their context.
+	Timeout time.Duration
+}
+
+// defaultClient is the Client used by the package functions
+var defaultClient = &Client{}
+
+// NewClient returns a Client with its own HTTP client, the
+// default token and a 30 second timeout
+//
+//	  client := pushover.NewClient(token)
+//	  resp, err := client.MessageContext(context.Background(),
+//	    pushover.MessageRequest{
+//		     User:    user,
+//		     Message: message,
+//	  })
+func NewClient(token string) *Client {
+	return &Client{
+		HTTPClient: &http.Client{},
+		Token:      token,
+		Timeout:    defaultClientTimeout,
+	}
+}
+
+// endpoint returns pushoverURL if set, otherwise the path
+// appended to the client's BaseURL if set, otherwise defaultURL
+func (c *Client) endpoint(pushoverURL, defaultURL, path string) string {
+	switch {
+	case len(pushoverURL) > 0:
+		return pushoverURL
+	case len(c.BaseURL) > 0:
+		return strings.TrimSuffix(c.BaseURL, "/") + path
+	}
+
+	return defaultURL
+}
+
+// token returns token if set, otherwise the client's Token
+func (c *Client) token(token string) string {
+	if len(token) == 0 {
+		return c.Token
+	}
+
+	return token
+}
+
+// transport returns the client's HTTP client, user agent and
+// timeout for submitting requests
+func (c *Client) transport() *api.Transport {
+	return &api.Transport{
+		HTTPClient: c.HTTPClient,
+		UserAgent:  c.UserAgent,
+		Timeout:    c.Timeout,
+	}
+}
@@ -0,0 +1,222 @@
+package pushover
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type clientRequest struct {
+	token     string
+	userAgent string
+}
+
+type clientServer struct {
+	mu       sync.Mutex
+	requests map[string]clientRequest
+}
+
+func (s *clientServer) handler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	s.mu.Lock()
+	s.requests[r.URL.Path] = clientRequest{token: r.Form.Get("token"), userAgent: r.UserAgent()}
+	s.mu.Unlock()
+
+	if r.Form.Get("token") == "slowtoken" {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"sounds":{"siren":"Siren"},"acknowledged":1,"status":1,"request":"%s"}`, id)
+}
+
+func (s *clientServer) request(path string) (clientRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.requests[path]
+
+	return r, ok
+}
+
+type countingTransport struct {
+	requests int32
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.requests, 1)
+
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestNewClient(t *testing.T) {
+	client := NewClient("testtoken")
+	if client.HTTPClient == nil || client.HTTPClient == http.DefaultClient ||
+		client.Token != "testtoken" || client.Timeout != defaultClientTimeout {
+		t.Error("New client")
+	}
+}
+
+func TestClientEndpoints(t *testing.T) {
+	server := &clientServer{requests: make(map[string]clientRequest)}
+	apiServer := httptest.NewServer(http.HandlerFunc(server.handler))
+	defer apiServer.Close()
+
+	transport := &countingTransport{}
+	client := NewClient("testtoken")
+	client.HTTPClient = &http.Client{Transport: transport}
+	client.BaseURL = apiServer.URL + "/1/"
+	client.UserAgent = "pushover-test/1.0"
+
+	ctx := context.TODO()
+	const group = "testgroup"
+	const receipt = "testreceipt"
+
+	calls := []struct {
+		path string
+		call func() error
+	}{
+		{"/1/messages.json", func() error {
+			_, e := client.MessageContext(ctx, MessageRequest{User: "testuser", Message: "message", Sound: "siren", VerifySound: true})
+			return e
+		}},
+		{"/1/sounds.json", func() error { _, e := client.SoundsContext(ctx, SoundsRequest{}); return e }},
+		{"/1/users/validate.json", func() error { _, e := client.ValidateContext(ctx, ValidateRequest{User: "testuser"}); return e }},
+		{"/1/receipts/" + receipt + ".json", func() error {
+			_, e := client.WaitForAcknowledgement(ctx, "", receipt, 0)
+			return e
+		}},
+		{"/1/receipts/" + receipt + "/cancel.json", func() error {
+			_, e := client.CancelReceiptContext(ctx, CancelReceiptRequest{Receipt: receipt})
+			return e
+		}},
+		{"/1/receipts/cancel_by_tag/tag.json", func() error {
+			_, e := client.CancelByTagContext(ctx, CancelByTagRequest{Tag: "tag"})
+			return e
+		}},
+		{"/1/apps/limits.json", func() error { _, e := client.LimitsContext(ctx, LimitsRequest{}); return e }},
+		{"/1/groups.json", func() error { _, e := client.ListGroupsContext(ctx, ListGroupsRequest{}); return e }},
+		{"/1/groups/" + group + ".json", func() error {
+			_, e := client.PlanGroupSync(ctx, GroupSyncRequest{Group: group, Members: []GroupMember{{User: "testuser"}}})
+			return e
+		}},
+		{"/1/groups/" + group + "/add_user.json", func() error {
+			_, e := client.ApplyGroupSync(ctx, GroupSyncRequest{Group: group},
+				&GroupSyncPlan{Changes: []GroupSyncChange{{Action: GroupSyncAdd, Member: GroupMember{User: "testuser"}}}})
+			return e
+		}},
+		{"/1/groups/" + group + "/delete_user.json", func() error {
+			_, e := client.RemoveGroupUserContext(ctx, GroupUserRequest{Group: group, User: "testuser"})
+			return e
+		}},
+		{"/1/groups/" + group + "/disable_user.json", func() error {
+			_, e := client.DisableGroupUserContext(ctx, GroupUserRequest{Group: group, User: "testuser"})
+			return e
+		}},
+		{"/1/groups/" + group + "/enable_user.json", func() error {
+			_, e := client.EnableGroupUserContext(ctx, GroupUserRequest{Group: group, User: "testuser"})
+			return e
+		}},
+		{"/1/groups/" + group + "/rename.json", func() error {
+			_, e := client.RenameGroupContext(ctx, RenameGroupRequest{Group: group, Name: "name"})
+			return e
+		}},
+		{"/1/glances.json", func() error { _, e := client.GlanceContext(ctx, GlanceRequest{User: "testuser", Count: "1"}); return e }},
+		{"/1/subscriptions/migrate.json", func() error {
+			_, e := client.MigrateSubscriptionContext(ctx, MigrateSubscriptionRequest{Subscription: "sub", User: "testuser"})
+			return e
+		}},
+		{"/1/licenses.json", func() error { _, e := client.LicenseCreditsContext(ctx, LicenseCreditsRequest{}); return e }},
+		{"/1/licenses/assign.json", func() error {
+			_, e := client.AssignLicenseContext(ctx, AssignLicenseRequest{User: "testuser"})
+			return e
+		}},
+		{"/1/teams/add_user.json", func() error {
+			_, e := client.AddTeamUserContext(ctx, AddTeamUserRequest{Email: "user@example.com"})
+			return e
+		}},
+		{"/1/teams/remove_user.json", func() error {
+			_, e := client.RemoveTeamUserContext(ctx, RemoveTeamUserRequest{Email: "user@example.com"})
+			return e
+		}},
+	}
+
+	for _, c := range calls {
+		if e := c.call(); e != nil {
+			t.Errorf("%s: %v", c.path, e)
+			continue
+		}
+
+		r, ok := server.request(c.path)
+		if !ok || r.token != "testtoken" || r.userAgent != client.UserAgent {
+			t.Errorf("%s: request %+v", c.path, r)
+		}
+	}
+
+	// The group is created at the base URL
+	if _, e := client.CreateGroupContext(ctx, CreateGroupRequest{Name: "name"}); e != nil {
+		t.Error("Create group")
+	}
+	if _, ok := server.request("/1/groups.json"); !ok {
+		t.Error("Create group URL")
+	}
+
+	if atomic.LoadInt32(&transport.requests) == 0 {
+		t.Error("HTTP client not used")
+	}
+
+	// Request URL and token override the client
+	override := httptest.NewServer(http.HandlerFunc(server.handler))
+	defer override.Close()
+
+	_, e := client.MessageContext(ctx, MessageRequest{PushoverURL: override.URL + "/override", Token: "requesttoken", User: "testuser", Message: "message"})
+	if r, ok := server.request("/override"); e != nil || !ok || r.token != "requesttoken" {
+		t.Error("Request override")
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := &clientServer{requests: make(map[string]clientRequest)}
+	apiServer := httptest.NewServer(http.HandlerFunc(server.handler))
+	defer apiServer.Close()
+
+	client := &Client{BaseURL: apiServer.URL, Token: "slowtoken", Timeout: 10 * time.Millisecond}
+
+	// Request exceeds the client timeout
+	_, e := client.SoundsContext(context.TODO(), SoundsRequest{})
+	if e != context.DeadlineExceeded {
+		t.Error("Client timeout exceeded")
+	}
+
+	// Response is read within the client timeout
+	client.Timeout = time.Second
+	r, e := client.SoundsContext(context.TODO(), SoundsRequest{})
+	if e != nil || r.APIStatus != 1 || r.Sounds["siren"] != "Siren" {
+		t.Error("Client timeout")
+	}
+
+	// Context cancellation is reported without a timeout
+	client.Timeout = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, e = client.SoundsContext(ctx, SoundsRequest{})
+	if e != context.Canceled {
+		t.Error("Context cancelled")
+	}
+
+	// Invalid URL
+	client.BaseURL = "://"
+	if _, e = client.SoundsContext(context.TODO(), SoundsRequest{}); e == nil {
+		t.Error("Invalid GET URL")
+	}
+	if _, e = client.ValidateContext(context.TODO(), ValidateRequest{}); e == nil {
+		t.Error("Invalid POST URL")
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd *cobra.Command
+
+func outputCancelReceiptRequest(r pushover.CancelReceiptRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Receipt", value: r.Receipt},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputCancelReceiptResponse(r pushover.CancelReceiptResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func outputCancelByTagRequest(r pushover.CancelByTagRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Tag", value: r.Tag},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputCancelByTagResponse(r pushover.CancelByTagResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+	fmt.Printf("%-*s %d\n", maxLen, "Canceled:", r.Canceled)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addCancelCmd(parentCmd *cobra.Command) {
+	var token, receipt, tag, pushoverURL string
+
+	cancelCmd = &cobra.Command{
+		Use:   "cancel",
+		Short: "Submit a cancel request",
+		Long: `Cancel the retries of an emergency (priority 2)
+notification by its receipt, or of all emergency
+notifications with a tag.
+
+The exit status is 1 if the retries could not be
+cancelled.
+
+Required options are:
+  --token
+  --receipt or --tag
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(receipt) == 0 && len(tag) == 0 {
+				fmt.Println("Error: one of --receipt or --tag is required")
+				osExit(1)
+				return
+			}
+
+			if len(tag) > 0 {
+				request := pushover.CancelByTagRequest{
+					PushoverURL: pushoverURL,
+					Token:       token,
+					Tag:         tag,
+				}
+
+				fmt.Println("Request")
+
+				outputCancelByTagRequest(request)
+
+				r, e := pushover.CancelByTag(request)
+
+				fmt.Println()
+				fmt.Println("Response")
+
+				if r != nil {
+					outputCancelByTagResponse(*r)
+				} else {
+					fmt.Println(e)
+				}
+
+				if e != nil {
+					osExit(1)
+				}
+
+				return
+			}
+
+			request := pushover.CancelReceiptRequest{
+				PushoverURL: pushoverURL,
+				Token:       token,
+				Receipt:     receipt,
+			}
+
+			fmt.Println("Request")
+
+			outputCancelReceiptRequest(request)
+
+			r, e := pushover.CancelReceipt(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputCancelReceiptResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if e != nil {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	cancelCmd.Flags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = cancelCmd.MarkFlagRequired(optionToken)
+	cancelCmd.Flags().StringVarP(&receipt, optionReceipt, "r", "", "Receipt from an emergency priority message")
+	cancelCmd.Flags().StringVarP(&tag, optionTag, "", "", "Tag of emergency priority messages")
+	cancelCmd.MarkFlagsMutuallyExclusive(optionReceipt, optionTag)
+
+	// Optional options
+	cancelCmd.Flags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	parentCmd.AddCommand(cancelCmd)
+}
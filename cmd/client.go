@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arcanericky/pushover"
+	"github.com/arcanericky/pushover/openclient"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var clientCmd *cobra.Command
+
+// envPassword is the environment variable holding the account
+// password for the client register command
+const envPassword = "PUSHOVER_PASSWORD"
+
+// clientState is the Open Client login and device registration
+// stored between runs of the client commands
+type clientState struct {
+	User       string `json:"user"`
+	Secret     string `json:"secret"`
+	DeviceID   string `json:"device_id"`
+	DeviceName string `json:"device_name"`
+}
+
+// defaultClientStateFile returns the path of the state file in
+// the user's configuration directory
+func defaultClientStateFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "pushover-client.json"
+	}
+
+	return filepath.Join(dir, "pushover", "client.json")
+}
+
+func readClientState(name string) (*clientState, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(clientState)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	if len(s.Secret) == 0 || len(s.DeviceID) == 0 {
+		return nil, fmt.Errorf("%s has no secret or device ID; run the client register command", name)
+	}
+
+	return s, nil
+}
+
+// writeClientState writes the state file readable only by the
+// user since it holds the user's secret
+func writeClientState(name string, s clientState) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, append(data, '\n'), 0600)
+}
+
+// newOpenClient returns a client for the Open Client API at
+// the Pushover API URL and WebSocket URL, or the default URLs
+// if empty
+func newOpenClient(pushoverURL, webSocketURL string) *openclient.Client {
+	return &openclient.Client{
+		Pushover:     &pushover.Client{BaseURL: pushoverURL},
+		WebSocketURL: webSocketURL,
+	}
+}
+
+// readPassword returns the account password from the
+// PUSHOVER_PASSWORD environment variable if set. Otherwise it
+// prompts for the password without echo if stdin is a
+// terminal, or reads the first line of stdin.
+func readPassword() (string, error) {
+	if password, ok := os.LookupEnv(envPassword); ok {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+
+		return string(password), err
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+		return "", fmt.Errorf("reading password from stdin: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// outputClientError prints the errors of a request rejected
+// by the Pushover API, or any other error
+func outputClientError(err error) {
+	var apiErr *pushover.APIError
+	if !errors.As(err, &apiErr) {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println("Status:", apiErr.HTTPStatus)
+	outputErrors(apiErr.Errors, apiErr.ErrorParameters)
+}
+
+func addClientRegisterCmd(parentCmd *cobra.Command, stateFile, pushoverURL *string) {
+	var email, password, twofa, name string
+
+	registerCmd := &cobra.Command{
+		Use:   "register",
+		Short: "Register this machine as an Open Client device",
+		Long: `Log in to a Pushover account and register a desktop device
+to receive messages with. The user's secret and the device
+ID are stored in the state file for the other client
+commands. This only needs to be done once per machine.
+
+A device name is 1 to 25 letters, numbers, _ and -.
+
+If the account has two-factor authentication enabled, repeat
+the command with the current code in --twofa.
+
+The password is read from the PUSHOVER_PASSWORD environment
+variable if set. Otherwise it is prompted for, or read from
+the first line of stdin when stdin is not a terminal. The
+--password option overrides these, but leaves the password in
+the shell history and process list.
+
+Required options are:
+  --email
+  --name
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := openclient.ValidateDeviceName(name); err != nil {
+				fmt.Println(err)
+				osExit(1)
+				return
+			}
+
+			if !cmd.Flags().Changed(optionPassword) {
+				var err error
+				if password, err = readPassword(); err != nil {
+					fmt.Println(err)
+					osExit(1)
+					return
+				}
+			}
+
+			client := newOpenClient(*pushoverURL, "")
+			ctx := context.Background()
+
+			login, err := client.Login(ctx, email, password, twofa)
+			if _, ok := err.(*openclient.ErrTwoFactorRequired); ok {
+				fmt.Println("Two-factor authentication code required; repeat with --twofa")
+				osExit(1)
+				return
+			}
+			if err != nil {
+				outputClientError(err)
+				osExit(1)
+				return
+			}
+
+			device, err := client.RegisterDevice(ctx, login.Secret, name)
+			if err != nil {
+				outputClientError(err)
+				osExit(1)
+				return
+			}
+
+			state := clientState{
+				User:       login.ID,
+				Secret:     login.Secret,
+				DeviceID:   device.ID,
+				DeviceName: name,
+			}
+
+			if err := writeClientState(*stateFile, state); err != nil {
+				fmt.Println("Error writing state file:", err)
+				osExit(1)
+				return
+			}
+
+			fmt.Println("Registered device", name, "with ID", device.ID)
+			fmt.Println("State saved to", *stateFile)
+		},
+	}
+
+	// Required options
+	registerCmd.Flags().StringVarP(&email, optionEmail, "e", "", "Pushover account email address")
+	_ = registerCmd.MarkFlagRequired(optionEmail)
+	registerCmd.Flags().StringVarP(&name, optionName, "n", "", "Name for this device")
+	_ = registerCmd.MarkFlagRequired(optionName)
+
+	// Optional options
+	registerCmd.Flags().StringVarP(&password, optionPassword, "", "", "Pushover account password, instead of "+envPassword)
+	registerCmd.Flags().StringVarP(&twofa, optionTwoFA, "", "", "Two-factor authentication code")
+
+	parentCmd.AddCommand(registerCmd)
+}
+
+func addClientAckCmd(parentCmd *cobra.Command, stateFile, pushoverURL *string) {
+	ackCmd := &cobra.Command{
+		Use:   "ack <receipt>",
+		Short: "Acknowledge an emergency priority message",
+		Long: `Acknowledge an emergency (priority 2) message by its
+receipt, stopping its retries. The receipt is shown for
+emergency messages by the inbox command and returned to the
+sender of the message.
+
+The exit status is 1 if the message could not be
+acknowledged.
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			state, err := readClientState(*stateFile)
+			if err != nil {
+				fmt.Println("Error reading state file:", err)
+				osExit(1)
+				return
+			}
+
+			client := newOpenClient(*pushoverURL, "")
+			if _, err := client.AcknowledgeMessage(context.Background(), state.Secret, args[0]); err != nil {
+				outputClientError(err)
+				osExit(1)
+				return
+			}
+
+			fmt.Println("Acknowledged", args[0])
+		},
+	}
+
+	parentCmd.AddCommand(ackCmd)
+}
+
+func addClientCmd(parentCmd *cobra.Command) {
+	var stateFile, pushoverURL string
+
+	clientCmd = &cobra.Command{
+		Use:   "client",
+		Short: "Receive messages as an Open Client device",
+		Long: `Register this machine as a Pushover desktop device and
+receive the messages sent to it with the Open Client API.
+
+The login and device registration are kept in a state file,
+by default in the user's configuration directory.
+`,
+	}
+
+	// Optional options
+	clientCmd.PersistentFlags().StringVarP(&stateFile, optionState, "", defaultClientStateFile(), "Client state file")
+	clientCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	addClientRegisterCmd(clientCmd, &stateFile, &pushoverURL)
+	addClientForwardCmd(clientCmd, &stateFile, &pushoverURL)
+	addClientAckCmd(clientCmd, &stateFile, &pushoverURL)
+
+	parentCmd.AddCommand(clientCmd)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/arcanericky/pushover/openclient"
+	"github.com/spf13/cobra"
+)
+
+// webhookTimeout is the longest time to wait for a webhook
+// to answer
+var webhookTimeout = 30 * time.Second
+
+// messageForwarder hands a received message to a command or
+// webhook
+type messageForwarder func(ctx context.Context, m openclient.ReceivedMessage) error
+
+// commandForwarder runs a command with the message as JSON
+// on stdin. The command's output is passed through.
+func commandForwarder(command string) messageForwarder {
+	return func(ctx context.Context, m openclient.ReceivedMessage) error {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		cmd := shellCommand(ctx, command)
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		return cmd.Run()
+	}
+}
+
+// webhookForwarder POSTs the message as JSON to a URL. Any
+// status other than 2xx is an error.
+func webhookForwarder(webhookURL string) messageForwarder {
+	client := &http.Client{Timeout: webhookTimeout}
+
+	return func(ctx context.Context, m openclient.ReceivedMessage) error {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook returned %s", resp.Status)
+		}
+
+		return nil
+	}
+}
+
+// forwardMessage forwards a message and deletes it, along with
+// any earlier messages, from the device
+func forwardMessage(ctx context.Context, client *openclient.Client, state *clientState, forward messageForwarder, m openclient.ReceivedMessage) error {
+	if err := forward(ctx, m); err != nil {
+		return fmt.Errorf("forwarding message %d: %w", m.ID, err)
+	}
+
+	if _, err := client.DeleteMessages(ctx, state.Secret, state.DeviceID, m.ID); err != nil {
+		return fmt.Errorf("deleting message %d: %w", m.ID, err)
+	}
+
+	fmt.Println(time.Now().Format(time.RFC3339), "Forwarded message", m.ID)
+
+	return nil
+}
+
+// forwardPending forwards the messages waiting on the device,
+// stopping at the first message that cannot be forwarded
+func forwardPending(ctx context.Context, client *openclient.Client, state *clientState, forward messageForwarder) error {
+	r, err := client.DownloadMessages(ctx, state.Secret, state.DeviceID)
+	if err != nil {
+		return fmt.Errorf("downloading messages: %w", err)
+	}
+
+	for _, m := range r.Messages {
+		if err := forwardMessage(ctx, client, state, forward, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forwardListen forwards messages as they arrive until the
+// context is done or a message cannot be forwarded
+func forwardListen(ctx context.Context, client *openclient.Client, state *clientState, forward messageForwarder) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	l := openclient.NewListener(state.Secret, state.DeviceID)
+	l.Client = client
+	l.ErrorHandler = func(err error) {
+		fmt.Println(time.Now().Format(time.RFC3339), "Reconnecting:", err)
+	}
+
+	messages := make(chan openclient.ReceivedMessage)
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- l.Listen(ctx, messages)
+	}()
+
+	for {
+		select {
+		case err := <-listenErr:
+			return err
+		case m := <-messages:
+			if err := forwardMessage(ctx, client, state, forward, m); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func addClientForwardCmd(parentCmd *cobra.Command, stateFile, pushoverURL *string) {
+	var command, webhook, webSocketURL string
+	var once bool
+
+	forwardCmd := &cobra.Command{
+		Use:   "forward",
+		Short: "Forward received messages to a command or webhook",
+		Long: `Forward the messages received by the Open Client device
+to a command or a webhook, then delete them from the device.
+
+With --command, the command is run for each message with
+the message as JSON on stdin. With --webhook, the message
+is POSTed as JSON to the URL. A message is forwarded when
+the command exits with status 0 or the webhook answers with
+a 2xx status.
+
+Messages are forwarded as they arrive until interrupted
+(Ctrl-C). With --once, the waiting messages are forwarded
+and the command exits.
+
+Forwarding stops with exit status 1 at the first message
+that cannot be forwarded. The message is left on the device
+to be forwarded on the next run.
+
+Required options are:
+  --command or --webhook
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var forward messageForwarder
+			switch {
+			case len(command) > 0:
+				forward = commandForwarder(command)
+			case len(webhook) > 0:
+				forward = webhookForwarder(webhook)
+			default:
+				fmt.Println("Error: one of --command or --webhook is required")
+				osExit(1)
+				return
+			}
+
+			state, err := readClientState(*stateFile)
+			if err != nil {
+				fmt.Println("Error reading state file:", err)
+				osExit(1)
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			client := newOpenClient(*pushoverURL, webSocketURL)
+			if once {
+				err = forwardPending(ctx, client, state, forward)
+			} else {
+				err = forwardListen(ctx, client, state, forward)
+			}
+
+			if err != nil && ctx.Err() == nil {
+				fmt.Println(err)
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	forwardCmd.Flags().StringVarP(&command, optionCommand, "c", "", "Command to run with each message on stdin")
+	forwardCmd.Flags().StringVarP(&webhook, optionWebhook, "", "", "URL to POST each message to")
+	forwardCmd.MarkFlagsMutuallyExclusive(optionCommand, optionWebhook)
+
+	// Optional options
+	forwardCmd.Flags().BoolVarP(&once, optionOnce, "", false, "Forward the waiting messages and exit")
+	forwardCmd.Flags().StringVarP(&webSocketURL, optionWebSocketURL, "", "", "Pushover Open Client WebSocket URL")
+
+	parentCmd.AddCommand(forwardCmd)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var glanceCmd *cobra.Command
+
+func outputGlanceRequest(r pushover.GlanceRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "User", value: r.User},
+		{field: "Device", value: r.Device},
+		{field: "Title", value: r.Title},
+		{field: "Text", value: r.Text},
+		{field: "Subtext", value: r.Subtext},
+		{field: "Count", value: r.Count},
+		{field: "Percent", value: r.Percent},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputGlanceResponse(r pushover.GlanceResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addGlanceCmd(parentCmd *cobra.Command) {
+	var token, user, device, title, text, subtext, pushoverURL string
+	var count, percent int
+
+	glanceCmd = &cobra.Command{
+		Use:   "glance",
+		Short: "Submit a glance request",
+		Long: `Update the data shown on a user's watch face or widget
+without sending a notification. Use the watch command to
+update the data from the output of a command.
+
+Required options are:
+  --token
+  --user
+and at least one of:
+  --title
+  --text
+  --subtext
+  --count
+  --percent
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.GlanceRequest{
+				PushoverURL: pushoverURL,
+				Token:       token,
+				User:        user,
+				Device:      device,
+				Title:       title,
+				Text:        text,
+				Subtext:     subtext,
+				Count:       intOptionToString(cmd, optionCount, count),
+				Percent:     intOptionToString(cmd, optionPercent, percent),
+			}
+
+			fmt.Println("Request")
+
+			outputGlanceRequest(request)
+
+			r, e := pushover.Glance(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputGlanceResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	glanceCmd.PersistentFlags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = glanceCmd.MarkPersistentFlagRequired(optionToken)
+	glanceCmd.PersistentFlags().StringVarP(&user, optionUser, "u", "", "User/Group key")
+	_ = glanceCmd.MarkPersistentFlagRequired(optionUser)
+
+	// Optional options
+	glanceCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+	glanceCmd.PersistentFlags().StringVarP(&device, optionDevice, "", "", "Device name to update")
+	glanceCmd.Flags().StringVarP(&title, optionTitle, "", "", "Description of the data")
+	glanceCmd.Flags().StringVarP(&text, optionText, "", "", "Main line of data")
+	glanceCmd.Flags().StringVarP(&subtext, optionSubtext, "", "", "Second line of data")
+	glanceCmd.Flags().IntVarP(&count, optionCount, "", 0, "Integer shown on smaller screens")
+	glanceCmd.Flags().IntVarP(&percent, optionPercent, "", 0, "Percentage (0 to 100) shown as a progress bar")
+
+	addGlanceWatchCmd(glanceCmd, &token, &user, &device, &pushoverURL)
+
+	parentCmd.AddCommand(glanceCmd)
+}
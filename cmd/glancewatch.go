@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+// minGlanceUpdateInterval is the shortest time between glance
+// updates. Pushover rate limits glance updates, so changes
+// within this interval are held until it has passed.
+var minGlanceUpdateInterval = time.Minute
+
+// glanceValues are the glance fields parsed from the output
+// of a watch command
+type glanceValues struct {
+	Title   string `json:"title"`
+	Text    string `json:"text"`
+	Subtext string `json:"subtext"`
+	Count   *int   `json:"count"`
+	Percent *int   `json:"percent"`
+}
+
+func (v glanceValues) equal(o glanceValues) bool {
+	intEqual := func(a, b *int) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+
+	return v.Title == o.Title && v.Text == o.Text && v.Subtext == o.Subtext &&
+		intEqual(v.Count, o.Count) && intEqual(v.Percent, o.Percent)
+}
+
+func (v glanceValues) apply(r *pushover.GlanceRequest) {
+	r.Title = v.Title
+	r.Text = v.Text
+	r.Subtext = v.Subtext
+	r.Count = ""
+	r.Percent = ""
+
+	if v.Count != nil {
+		r.Count = strconv.Itoa(*v.Count)
+	}
+
+	if v.Percent != nil {
+		r.Percent = strconv.Itoa(*v.Percent)
+	}
+}
+
+// parseGlanceOutput parses the output of a watch command. The
+// output is either a plain number, stored in the numberField
+// ("count" or "percent"), or a JSON object with title, text,
+// subtext, count and percent keys.
+func parseGlanceOutput(output []byte, numberField string) (glanceValues, error) {
+	var v glanceValues
+
+	output = bytes.TrimSpace(output)
+	if len(output) == 0 {
+		return v, errors.New("command output is empty")
+	}
+
+	if output[0] == '{' {
+		if err := json.Unmarshal(output, &v); err != nil {
+			return v, fmt.Errorf("command output is not valid JSON: %w", err)
+		}
+
+		return v, nil
+	}
+
+	f, err := strconv.ParseFloat(string(output), 64)
+	if err != nil {
+		return v, fmt.Errorf("command output is not a number or JSON: %q", output)
+	}
+
+	n := int(math.Round(f))
+	switch numberField {
+	case optionCount:
+		v.Count = &n
+	case optionPercent:
+		v.Percent = &n
+	default:
+		return v, fmt.Errorf("invalid number field %q", numberField)
+	}
+
+	return v, nil
+}
+
+// watchGlance runs a command every interval and submits a
+// glance update when its output changes, until the context
+// is done
+func watchGlance(ctx context.Context, request pushover.GlanceRequest, command, numberField string, interval time.Duration) {
+	var last *glanceValues
+	var lastUpdate time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		output, err := shellCommand(ctx, command).Output()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Println(time.Now().Format(time.RFC3339), "Command error:", err)
+		} else if v, err := parseGlanceOutput(output, numberField); err != nil {
+			fmt.Println(time.Now().Format(time.RFC3339), "Output error:", err)
+		} else if (last == nil || !v.equal(*last)) && time.Since(lastUpdate) >= minGlanceUpdateInterval {
+			v.apply(&request)
+
+			var apiErr *pushover.APIError
+			_, err := pushover.GlanceContext(ctx, request)
+			switch {
+			case errors.As(err, &apiErr):
+				fmt.Println(time.Now().Format(time.RFC3339), "Glance rejected:", apiErr.HTTPStatus, apiErr.Errors)
+				// Hold further updates after a rejection such as
+				// a rate limit
+				lastUpdate = time.Now()
+			case err != nil:
+				if ctx.Err() != nil {
+					return
+				}
+				fmt.Println(time.Now().Format(time.RFC3339), "Glance error:", err)
+			default:
+				fmt.Println(time.Now().Format(time.RFC3339), "Glance updated:", string(bytes.TrimSpace(output)))
+				last = &v
+				lastUpdate = time.Now()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func addGlanceWatchCmd(parentCmd *cobra.Command, token, user, device, pushoverURL *string) {
+	var command, numberField string
+	var interval time.Duration
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Update a glance from the output of a command",
+		Long: `Run a command on a schedule and update a glance when
+its output changes. The output is either a plain number,
+shown as the count (or percent with --number-field percent),
+or a JSON object with any of the keys:
+
+  {"title": "Builds", "text": "3 queued", "subtext": "2 running",
+   "count": 3, "percent": 42}
+
+Pushover rate limits glance updates, so updates are sent at
+most once a minute. Stop watching with an interrupt (Ctrl-C).
+
+Required options are:
+  --token
+  --user
+  --command
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if interval <= 0 {
+				fmt.Println("Error: interval must be greater than zero")
+				return
+			}
+
+			request := pushover.GlanceRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				User:        *user,
+				Device:      *device,
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			watchGlance(ctx, request, command, numberField, interval)
+		},
+	}
+
+	// Required options
+	watchCmd.Flags().StringVarP(&command, optionCommand, "c", "", "Command whose output is the glance data")
+	_ = watchCmd.MarkFlagRequired(optionCommand)
+
+	// Optional options
+	watchCmd.Flags().DurationVarP(&interval, optionInterval, "", 5*time.Minute, "Time between command runs")
+	watchCmd.Flags().StringVarP(&numberField, optionNumberField, "", optionCount, "Glance field for plain number output (count or percent)")
+
+	parentCmd.AddCommand(watchCmd)
+}
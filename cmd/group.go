@@ -0,0 +1,413 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var groupCmd *cobra.Command
+
+func outputGroupRequest(pushoverURL, token, group, name string) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: pushoverURL},
+		{field: "Token", value: token},
+		{field: "Group", value: group},
+		{field: "Name", value: name},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputCreateGroupResponse(r pushover.CreateGroupResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	if len(r.Group) > 0 {
+		fmt.Printf("%-*s %s\n", maxLen, "Group:", r.Group)
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func outputListGroupsResponse(r pushover.ListGroupsResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	fmt.Println("Groups:")
+	for _, v := range r.Groups {
+		fmt.Println(" ", v.Key, v.Name)
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func outputGroupInfoResponse(r pushover.GroupInfoResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+	fmt.Printf("%-*s %s\n", maxLen, "Name:", r.Group.Name)
+
+	fmt.Println("Users:")
+	for _, v := range r.Group.Users {
+		fmt.Printf("  %s\n", v.User)
+		if len(v.Device) > 0 {
+			fmt.Printf("    Device:   %s\n", v.Device)
+		}
+		if len(v.Memo) > 0 {
+			fmt.Printf("    Memo:     %s\n", v.Memo)
+		}
+		fmt.Printf("    Disabled: %t\n", v.Disabled)
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addGroupCreateCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var name string
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Submit a group create request",
+		Long: `Create a new, empty delivery group.
+
+Required options are:
+  --token
+  --name
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.CreateGroupRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				Name:        name,
+			}
+
+			fmt.Println("Request")
+
+			outputGroupRequest(request.PushoverURL, request.Token, "", request.Name)
+
+			r, e := pushover.CreateGroup(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputCreateGroupResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	createCmd.Flags().StringVarP(&name, optionName, "n", "", "Group name")
+	_ = createCmd.MarkFlagRequired(optionName)
+
+	parentCmd.AddCommand(createCmd)
+}
+
+func addGroupListCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Submit a group list request",
+		Long: `List the delivery groups owned by the account.
+
+Required options are:
+  --token
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.ListGroupsRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+			}
+
+			fmt.Println("Request")
+
+			outputGroupRequest(request.PushoverURL, request.Token, "", "")
+
+			r, e := pushover.ListGroups(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputListGroupsResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	parentCmd.AddCommand(listCmd)
+}
+
+func addGroupShowCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var group string
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Submit a group information request",
+		Long: `Show the name and members of a delivery group.
+
+Required options are:
+  --token
+  --group
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.GroupInfoRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				Group:       group,
+			}
+
+			fmt.Println("Request")
+
+			outputGroupRequest(request.PushoverURL, request.Token, request.Group, "")
+
+			r, e := pushover.GroupInfo(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputGroupInfoResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	showCmd.Flags().StringVarP(&group, optionGroup, "g", "", "Group key")
+	_ = showCmd.MarkFlagRequired(optionGroup)
+
+	parentCmd.AddCommand(showCmd)
+}
+
+func outputGroupUpdateResponse(r pushover.GroupUpdateResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func outputGroupUserRequest(r pushover.GroupUserRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Group", value: r.Group},
+		{field: "User", value: r.User},
+		{field: "Device", value: r.Device},
+		{field: "Memo", value: r.Memo},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func addGroupUserCmd(parentCmd *cobra.Command, token, pushoverURL *string, use, short, long string,
+	withMemo bool, update func(pushover.GroupUserRequest) (*pushover.GroupUpdateResponse, error)) {
+	var group, user, device, memo string
+
+	userCmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: long + `
+
+The exit status is 1 if the change could not be made.
+
+Required options are:
+  --token
+  --group
+  --user
+`,
+	}
+
+	userCmd.Run = func(cmd *cobra.Command, args []string) {
+		request := pushover.GroupUserRequest{
+			PushoverURL: *pushoverURL,
+			Token:       *token,
+			Group:       group,
+			User:        user,
+			Device:      device,
+			Memo:        memo,
+		}
+
+		fmt.Println("Request")
+
+		outputGroupUserRequest(request)
+
+		r, e := update(request)
+
+		fmt.Println()
+		fmt.Println("Response")
+
+		if r != nil {
+			outputGroupUpdateResponse(*r)
+		} else {
+			fmt.Println(e)
+		}
+
+		if e != nil {
+			osExit(1)
+		}
+	}
+
+	// Required options
+	userCmd.Flags().StringVarP(&group, optionGroup, "g", "", "Group key")
+	_ = userCmd.MarkFlagRequired(optionGroup)
+	userCmd.Flags().StringVarP(&user, optionUser, "u", "", "User key")
+	_ = userCmd.MarkFlagRequired(optionUser)
+
+	// Optional options
+	userCmd.Flags().StringVarP(&device, optionDevice, "", "", "User's device name")
+	if withMemo {
+		userCmd.Flags().StringVarP(&memo, optionMemo, "", "", "Free-form note about the user")
+	}
+
+	parentCmd.AddCommand(userCmd)
+}
+
+func addGroupRenameCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var group, name string
+
+	renameCmd := &cobra.Command{
+		Use:   "rename",
+		Short: "Submit a group rename request",
+		Long: `Change the name of a delivery group.
+
+The exit status is 1 if the group could not be renamed.
+
+Required options are:
+  --token
+  --group
+  --name
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.RenameGroupRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				Group:       group,
+				Name:        name,
+			}
+
+			fmt.Println("Request")
+
+			outputGroupRequest(request.PushoverURL, request.Token, request.Group, request.Name)
+
+			r, e := pushover.RenameGroup(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputGroupUpdateResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if e != nil {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	renameCmd.Flags().StringVarP(&group, optionGroup, "g", "", "Group key")
+	_ = renameCmd.MarkFlagRequired(optionGroup)
+	renameCmd.Flags().StringVarP(&name, optionName, "n", "", "New group name")
+	_ = renameCmd.MarkFlagRequired(optionName)
+
+	parentCmd.AddCommand(renameCmd)
+}
+
+func addGroupCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+
+	groupCmd = &cobra.Command{
+		Use:   "group",
+		Short: "Submit delivery group requests",
+		Long: `Create, list, show, rename and manage the members of
+Pushover delivery groups, including synchronizing the
+members with a file.
+
+Required options for all group commands are:
+  --token
+`,
+	}
+
+	// Required options
+	groupCmd.PersistentFlags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = groupCmd.MarkPersistentFlagRequired(optionToken)
+
+	// Optional options
+	groupCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	addGroupCreateCmd(groupCmd, &token, &pushoverURL)
+	addGroupListCmd(groupCmd, &token, &pushoverURL)
+	addGroupShowCmd(groupCmd, &token, &pushoverURL)
+	addGroupRenameCmd(groupCmd, &token, &pushoverURL)
+	addGroupSyncCmd(groupCmd, &token, &pushoverURL)
+
+	addGroupUserCmd(groupCmd, &token, &pushoverURL, "add",
+		"Submit a group add user request",
+		"Add a user, and optionally one of their devices, to a\ndelivery group.",
+		true, pushover.AddGroupUser)
+	addGroupUserCmd(groupCmd, &token, &pushoverURL, "remove",
+		"Submit a group remove user request",
+		"Remove a user from a delivery group.",
+		false, pushover.RemoveGroupUser)
+	addGroupUserCmd(groupCmd, &token, &pushoverURL, "disable",
+		"Submit a group disable user request",
+		"Temporarily stop a user from receiving messages sent to\na delivery group.",
+		false, pushover.DisableGroupUser)
+	addGroupUserCmd(groupCmd, &token, &pushoverURL, "enable",
+		"Submit a group enable user request",
+		"Re-enable a user previously disabled in a delivery\ngroup.",
+		false, pushover.EnableGroupUser)
+
+	parentCmd.AddCommand(groupCmd)
+}
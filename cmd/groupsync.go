@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// groupSyncFile is the desired membership of a group
+//
+//	group: gznej3rKEVAvPUxu9vvNnqpmZpokzF
+//	members:
+//	  - user: uQiRzpo4DXghDmr9QzzfQu27cmVRsG
+//	    device: pixel2xl
+//	    memo: Alice
+//	    disabled: false
+type groupSyncFile struct {
+	Group   string `yaml:"group"`
+	Members []struct {
+		User     string `yaml:"user"`
+		Device   string `yaml:"device"`
+		Memo     string `yaml:"memo"`
+		Disabled bool   `yaml:"disabled"`
+	} `yaml:"members"`
+}
+
+func readGroupSyncFile(name string) (*groupSyncFile, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f := new(groupSyncFile)
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func groupMemberText(m pushover.GroupMember) string {
+	text := m.User
+
+	if len(m.Device) > 0 {
+		text += " device=" + m.Device
+	}
+
+	if len(m.Memo) > 0 {
+		text += fmt.Sprintf(" memo=%q", m.Memo)
+	}
+
+	if m.Disabled {
+		text += " disabled"
+	}
+
+	return text
+}
+
+func outputGroupSyncPlan(plan pushover.GroupSyncPlan) {
+	fmt.Println("Plan:")
+	if len(plan.Changes) == 0 {
+		fmt.Println("  No changes")
+	}
+	for _, v := range plan.Changes {
+		fmt.Printf("  %-7s %s\n", v.Action, groupMemberText(v.Member))
+	}
+
+	if len(plan.Invalid) > 0 {
+		fmt.Println("Invalid Members:")
+		for _, v := range plan.Invalid {
+			fmt.Printf("  %s\n", groupMemberText(v.Member))
+			for _, e := range v.Errors {
+				fmt.Println("   ", e)
+			}
+		}
+	}
+}
+
+func outputGroupSyncResults(results []pushover.GroupSyncResult) bool {
+	ok := true
+
+	fmt.Println("Results:")
+	for _, v := range results {
+		status := "done"
+		for _, r := range v.Responses {
+			if r.APIStatus != 1 {
+				status = "failed"
+				ok = false
+			}
+		}
+
+		fmt.Printf("  %-7s %s: %s\n", v.Change.Action, groupMemberText(v.Change.Member), status)
+		for _, r := range v.Responses {
+			for _, e := range r.Errors {
+				fmt.Println("   ", e)
+			}
+		}
+	}
+
+	return ok
+}
+
+func addGroupSyncCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var group, file, validateURL string
+	var dryRun bool
+
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Synchronize group members with a file",
+		Long: `Change the members of a delivery group to match a YAML
+file. The changes are printed before they are applied.
+New members are validated before they are added. With
+--pushoverurl, they are validated only if --validateurl
+is also given.
+
+The file lists the desired members:
+
+  group: gznej3rKEVAvPUxu9vvNnqpmZpokzF
+  members:
+    - user: uQiRzpo4DXghDmr9QzzfQu27cmVRsG
+      device: pixel2xl
+      memo: Alice
+      disabled: false
+
+The exit status is 1 if the group could not be synchronized.
+
+Required options are:
+  --token
+  --file
+  --group, unless the group is in the file
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := readGroupSyncFile(file)
+			if err != nil {
+				fmt.Println("Error reading members file:", err)
+				osExit(1)
+				return
+			}
+
+			if len(group) == 0 {
+				group = f.Group
+			}
+
+			if len(group) == 0 {
+				fmt.Println("Error: group is not set in the file or with --group")
+				osExit(1)
+				return
+			}
+
+			request := pushover.GroupSyncRequest{
+				PushoverURL: *pushoverURL,
+				ValidateURL: validateURL,
+				Token:       *token,
+				Group:       group,
+				Members:     []pushover.GroupMember{},
+			}
+
+			for _, m := range f.Members {
+				request.Members = append(request.Members, pushover.GroupMember{
+					User:     m.User,
+					Device:   m.Device,
+					Memo:     m.Memo,
+					Disabled: m.Disabled,
+				})
+			}
+
+			fmt.Println("Request")
+
+			outputGroupRequest(request.PushoverURL, request.Token, request.Group, "")
+
+			plan, err := pushover.PlanGroupSync(context.Background(), request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if plan == nil {
+				fmt.Println(err)
+				osExit(1)
+				return
+			}
+
+			if err != nil {
+				outputGroupInfoResponse(*plan.Current)
+				osExit(1)
+				return
+			}
+
+			outputGroupSyncPlan(*plan)
+
+			if dryRun {
+				return
+			}
+
+			results, err := pushover.ApplyGroupSync(context.Background(), request, plan)
+			ok := outputGroupSyncResults(results)
+
+			if err != nil {
+				fmt.Println(err)
+				ok = false
+			}
+
+			if !ok || len(plan.Invalid) > 0 {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	syncCmd.Flags().StringVarP(&file, optionFile, "f", "", "YAML file of group members")
+	_ = syncCmd.MarkFlagRequired(optionFile)
+
+	// Optional options
+	syncCmd.Flags().StringVarP(&group, optionGroup, "g", "", "Group key")
+	syncCmd.Flags().BoolVarP(&dryRun, optionDryRun, "", false, "Print the changes without applying them")
+	syncCmd.Flags().StringVarP(&validateURL, optionValidateURL, "", "", "Pushover validate API URL")
+
+	parentCmd.AddCommand(syncCmd)
+}
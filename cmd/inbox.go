@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/arcanericky/pushover/openclient"
+	"github.com/spf13/cobra"
+)
+
+var inboxCmd *cobra.Command
+
+func outputReceivedMessages(w io.Writer, messages []openclient.ReceivedMessage) {
+	textLen := len("Priority:")
+
+	for i, m := range messages {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		fields := []struct {
+			field string
+			value string
+		}{
+			{field: "ID", value: fmt.Sprint(m.ID)},
+			{field: "Date", value: timeToString(m.Date)},
+			{field: "App", value: m.App},
+			{field: "Priority", value: fmt.Sprint(m.Priority)},
+			{field: "Title", value: m.Title},
+			{field: "Message", value: m.Message},
+			{field: "URL", value: m.URL},
+			{field: "Receipt", value: m.Receipt},
+		}
+
+		for _, f := range fields {
+			if len(f.value) > 0 {
+				fmt.Fprintf(w, "%-*s %s\n", textLen, f.field+":", f.value)
+			}
+		}
+	}
+}
+
+func addInboxCmd(parentCmd *cobra.Command) {
+	var stateFile, pushoverURL string
+	var jsonOutput, clearMessages bool
+
+	inboxCmd = &cobra.Command{
+		Use:   "inbox",
+		Short: "Download the messages sent to this device",
+		Long: `Download and print the messages waiting on the Open Client
+device registered with the client register command. The
+messages are printed as text, or as a JSON array with --json.
+
+Messages stay on the device until they are cleared with
+--clear, which deletes the messages printed.
+
+The exit status is 1 if the messages could not be
+downloaded or cleared.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			state, err := readClientState(stateFile)
+			if err != nil {
+				fmt.Println("Error reading state file:", err)
+				osExit(1)
+				return
+			}
+
+			client := newOpenClient(pushoverURL, "")
+			ctx := context.Background()
+
+			r, err := client.DownloadMessages(ctx, state.Secret, state.DeviceID)
+			if err != nil {
+				outputClientError(err)
+				osExit(1)
+				return
+			}
+
+			if jsonOutput {
+				data, _ := json.MarshalIndent(r.Messages, "", "  ")
+				fmt.Println(string(data))
+			} else {
+				outputReceivedMessages(os.Stdout, r.Messages)
+			}
+
+			if !clearMessages || len(r.Messages) == 0 {
+				return
+			}
+
+			if _, err := client.DeleteMessages(ctx, state.Secret, state.DeviceID, r.HighestID()); err != nil {
+				fmt.Fprintln(os.Stderr, "Error clearing messages:", err)
+				osExit(1)
+			}
+		},
+	}
+
+	// Optional options
+	inboxCmd.Flags().StringVarP(&stateFile, optionState, "", defaultClientStateFile(), "Client state file")
+	inboxCmd.Flags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+	inboxCmd.Flags().BoolVarP(&jsonOutput, optionJSON, "", false, "Print the messages as JSON")
+	inboxCmd.Flags().BoolVarP(&clearMessages, optionClear, "", false, "Delete the messages after printing them")
+
+	parentCmd.AddCommand(inboxCmd)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var licenseCmd *cobra.Command
+
+func outputLicenseRequest(pushoverURL, token, user, email, platform string) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: pushoverURL},
+		{field: "Token", value: token},
+		{field: "User", value: user},
+		{field: "Email", value: email},
+		{field: "OS", value: platform},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputLicenseCreditsResponse(r pushover.LicenseCreditsResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	if r.APIStatus == 1 {
+		fmt.Printf("%-*s %d\n", maxLen, "Credits:", r.Credits)
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func outputAssignLicenseResponse(r pushover.AssignLicenseResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addLicenseCreditsCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	creditsCmd := &cobra.Command{
+		Use:   "credits",
+		Short: "Submit a license credits request",
+		Long:  `Show the number of license credits remaining for the application.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.LicenseCreditsRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+			}
+
+			fmt.Println("Request")
+
+			outputLicenseRequest(request.PushoverURL, request.Token, "", "", "")
+
+			r, e := pushover.LicenseCredits(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputLicenseCreditsResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	parentCmd.AddCommand(creditsCmd)
+}
+
+func addLicenseAssignCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var user, email, platform string
+
+	assignCmd := &cobra.Command{
+		Use:   "assign",
+		Short: "Submit a license assign request",
+		Long: `Assign a license credit to a user by their user key or
+email address. If no Pushover account exists for the email
+address, one is created.
+
+The license can be limited to a platform with --os set to
+Android, iOS or Desktop.
+
+The exit status is 1 if the license could not be assigned.
+
+Required options are:
+  --token
+  --user or --email
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(user) == 0 && len(email) == 0 {
+				fmt.Println("Error: one of --user or --email is required")
+				osExit(1)
+				return
+			}
+
+			request := pushover.AssignLicenseRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				User:        user,
+				Email:       email,
+				OS:          platform,
+			}
+
+			fmt.Println("Request")
+
+			outputLicenseRequest(request.PushoverURL, request.Token, request.User, request.Email, request.OS)
+
+			r, e := pushover.AssignLicense(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputAssignLicenseResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if e != nil {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	assignCmd.Flags().StringVarP(&user, optionUser, "u", "", "User key to assign the license to")
+	assignCmd.Flags().StringVarP(&email, optionEmail, "e", "", "Email address to assign the license to")
+	assignCmd.MarkFlagsMutuallyExclusive(optionUser, optionEmail)
+
+	// Optional options
+	assignCmd.Flags().StringVarP(&platform, optionOS, "", "", "Platform for the license (Android, iOS or Desktop)")
+
+	parentCmd.AddCommand(assignCmd)
+}
+
+func addLicenseCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+
+	licenseCmd = &cobra.Command{
+		Use:   "license",
+		Short: "Submit license requests",
+		Long: `Check the license credits remaining for an application and
+assign licenses to users.
+
+Required options for all license commands are:
+  --token
+`,
+	}
+
+	// Required options
+	licenseCmd.PersistentFlags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = licenseCmd.MarkPersistentFlagRequired(optionToken)
+
+	// Optional options
+	licenseCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	addLicenseCreditsCmd(licenseCmd, &token, &pushoverURL)
+	addLicenseAssignCmd(licenseCmd, &token, &pushoverURL)
+
+	parentCmd.AddCommand(licenseCmd)
+}
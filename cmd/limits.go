@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var limitsCmd *cobra.Command
+
+func outputLimitsRequest(r pushover.LimitsRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputLimitsResponse(r pushover.LimitsResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	if r.APIStatus == 1 {
+		fmt.Printf("%-*s %d\n", maxLen, "Limit:", r.Limit)
+		fmt.Printf("%-*s %d\n", maxLen, "Remaining:", r.Remaining)
+		fmt.Printf("%-*s %s\n", maxLen, "Reset:", timeToString(r.Reset))
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addLimitsCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+	var warnBelow int
+
+	limitsCmd = &cobra.Command{
+		Use:   "limits",
+		Short: "Submit a limits request",
+		Long: `Retrieve the monthly message limit of an application
+and the number of messages remaining.
+
+When --warn-below is given, the exit status is 1 if the
+number of remaining messages is below the threshold or
+could not be retrieved.
+
+Required options are:
+  --token
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.LimitsRequest{
+				PushoverURL: pushoverURL,
+				Token:       token,
+			}
+
+			fmt.Println("Request")
+
+			outputLimitsRequest(request)
+
+			r, e := pushover.Limits(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputLimitsResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if !cmd.Flags().Changed(optionWarnBelow) {
+				return
+			}
+
+			if e != nil {
+				osExit(1)
+				return
+			}
+
+			if r.Remaining < warnBelow {
+				fmt.Println()
+				fmt.Printf("Warning: %d messages remaining is below %d\n", r.Remaining, warnBelow)
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	limitsCmd.Flags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = limitsCmd.MarkFlagRequired(optionToken)
+
+	// Optional options
+	limitsCmd.Flags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+	limitsCmd.Flags().IntVarP(&warnBelow, optionWarnBelow, "", 0, "Exit with status 1 when fewer messages remain")
+
+	parentCmd.AddCommand(limitsCmd)
+}
@@ -1,41 +1,123 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
 	"runtime"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 const (
-	optionCallback    = "callback"
-	optionDevice      = "device"
-	optionExpire      = "expire"
-	optionHTML        = "html"
-	optionImage       = "image"
-	optionMessage     = "message"
-	optionMonospace   = "monospace"
-	optionPriority    = "priority"
-	optionPushoverURL = "pushoverurl"
-	optionRetry       = "retry"
-	optionSound       = "sound"
-	optionTimestamp   = "timestamp"
-	optionTitle       = "title"
-	optionToken       = "token"
-	optionURL         = "url"
-	optionURLTitle    = "urltitle"
-	optionUser        = "user"
+	optionAdmin        = "admin"
+	optionCallback     = "callback"
+	optionClear        = "clear"
+	optionCommand      = "command"
+	optionCount        = "count"
+	optionDevice       = "device"
+	optionDryRun       = "dry-run"
+	optionEmail        = "email"
+	optionExpire       = "expire"
+	optionFile         = "file"
+	optionGroup        = "group"
+	optionHTML         = "html"
+	optionImage        = "image"
+	optionInstant      = "instant"
+	optionInterval     = "interval"
+	optionJSON         = "json"
+	optionMemo         = "memo"
+	optionMessage      = "message"
+	optionMonospace    = "monospace"
+	optionName         = "name"
+	optionNumberField  = "number-field"
+	optionOnce         = "once"
+	optionOS           = "os"
+	optionPassword     = "password"
+	optionPercent      = "percent"
+	optionPriority     = "priority"
+	optionPushoverURL  = "pushoverurl"
+	optionReceipt      = "receipt"
+	optionRetry        = "retry"
+	optionSound        = "sound"
+	optionState        = "state"
+	optionSubscription = "subscription"
+	optionSubtext      = "subtext"
+	optionTag          = "tag"
+	optionTags         = "tags"
+	optionText         = "text"
+	optionTimestamp    = "timestamp"
+	optionTitle        = "title"
+	optionToken        = "token"
+	optionTwoFA        = "twofa"
+	optionURL          = "url"
+	optionURLTitle     = "urltitle"
+	optionUser         = "user"
+	optionValidateURL  = "validateurl"
+	optionWarnBelow    = "warn-below"
+	optionWebSocketURL = "websocketurl"
+	optionWebhook      = "webhook"
 )
 
 var versionText string
 
+// osExit is replaced by tests to check the exit status
+var osExit = os.Exit
+
+// shellCommand returns a command that runs command with the
+// system shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	return exec.CommandContext(ctx, shell, flag, command)
+}
+
+func outputErrors(errors []string, errorParameters map[string]string) {
+	if len(errorParameters) > 0 {
+		maxLen := 0
+		for k := range errorParameters {
+			curLen := len(k)
+			if curLen > maxLen {
+				maxLen = curLen
+			}
+		}
+		maxLen++
+
+		fmt.Println("Parameter Errors:")
+		for k, v := range errorParameters {
+			fmt.Printf("  %-*s %s\n", maxLen, k+":", v)
+		}
+	}
+
+	if len(errors) > 0 {
+		fmt.Println("Errors:")
+		for _, v := range errors {
+			fmt.Println(" ", v)
+		}
+	}
+}
+
+func timeToString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "pushover",
 		Short: "Pushover",
 		Long: `Pushover CLI version ` + versionText + `
 
-Submit various requests to the Pushover API. Currently only
-message (notification) and validate are supported.
+Submit various requests to the Pushover API. See the list of
+available commands below for the supported requests.
 
 See the README at https://github.com/arcanericky/pushover for
 more information. For details on Pushover, see
@@ -46,6 +128,17 @@ https://pushover.net/.`,
 
 	addMessageCmd(rootCmd)
 	addValidateCmd(rootCmd)
+	addReceiptCmd(rootCmd)
+	addCancelCmd(rootCmd)
+	addSoundsCmd(rootCmd)
+	addLimitsCmd(rootCmd)
+	addGroupCmd(rootCmd)
+	addGlanceCmd(rootCmd)
+	addSubscriptionCmd(rootCmd)
+	addLicenseCmd(rootCmd)
+	addTeamCmd(rootCmd)
+	addClientCmd(rootCmd)
+	addInboxCmd(rootCmd)
 
 	_ = rootCmd.Execute()
 }
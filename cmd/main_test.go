@@ -1,11 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+	"golang.org/x/net/websocket"
 )
 
 const id = "deadbeef-dead-beef-dead-deadbeefdead"
@@ -23,6 +32,9 @@ func serverMessageHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("X-Limit-App-Limit", "10000")
+	w.Header().Set("X-Limit-App-Remaining", "7496")
+	w.Header().Set("X-Limit-App-Reset", "1393653600")
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, `{"status":1,"request":"%s","receipt":"1337"}`, id)
@@ -54,6 +66,13 @@ func TestPushoverMessageCLI(t *testing.T) {
 	// Nothing to check - exercising code
 	main()
 
+	// Emergency priority with tags
+	os.Args = baseArgs
+	os.Args = append(os.Args, "--priority", "2", "--retry", "30", "--expire", "60", "--tags", "incident")
+
+	// Nothing to check - exercising code
+	main()
+
 	// Test image attachment with valid file
 	os.Args = baseArgs
 	os.Args = append(os.Args, "--image", savedArgs[0])
@@ -124,3 +143,1228 @@ func TestPushoverValidateCLI(t *testing.T) {
 
 	os.Args = savedArgs
 }
+
+func serverReceiptHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"acknowledged":1,"acknowledged_at":1360019238,"acknowledged_by":"user","acknowledged_by_device":"pixel2xl","last_delivered_at":1360001238,"expired":1,"expires_at":1360019290,"called_back":0,"called_back_at":0,"request":"%s"}`, id)
+}
+
+func TestPushoverReceiptCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverReceiptHandler))
+	defer apiServer.Close()
+
+	// Test valid input and output
+	savedArgs := os.Args
+	os.Args = []string{
+		"pushover",
+		"receipt",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+		"--receipt", "receipt",
+	}
+
+	// Nothing to check - exercising code
+	main()
+
+	// Nothing to check - exercising code
+	os.Args[5] = "fail"
+	main()
+
+	// Test no server
+	apiServer.Close()
+	main()
+
+	os.Args = savedArgs
+}
+
+func serverCancelHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverCancelCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverCancelHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	// Test valid input and output
+	savedArgs := os.Args
+	os.Args = []string{
+		"pushover",
+		"cancel",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+		"--receipt", "receipt",
+	}
+
+	main()
+	if exitCode != 0 {
+		t.Error("Cancel receipt")
+	}
+
+	os.Args[5] = "fail"
+	main()
+	if exitCode != 1 {
+		t.Error("Cancel receipt rejected")
+	}
+
+	// Cancel by tag
+	os.Args = []string{
+		"pushover",
+		"cancel",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+		"--tag", "incident",
+	}
+
+	exitCode = 0
+	main()
+	if exitCode != 0 {
+		t.Error("Cancel by tag")
+	}
+
+	os.Args[5] = "fail"
+	main()
+	if exitCode != 1 {
+		t.Error("Cancel by tag rejected")
+	}
+	os.Args[5] = "token"
+
+	// Missing receipt and tag
+	os.Args = os.Args[:len(os.Args)-2]
+
+	exitCode = 0
+	main()
+	if exitCode != 1 {
+		t.Error("Missing receipt and tag")
+	}
+
+	os.Args = append(os.Args, "--tag", "incident")
+
+	// Test no server
+	apiServer.Close()
+	exitCode = 0
+	main()
+	if exitCode != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func serverSoundsHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"sounds":{"pushover":"Pushover (default)","bike":"Bike","siren":"Siren"},"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverSoundsCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverSoundsHandler))
+	defer apiServer.Close()
+
+	// Test valid input and output
+	savedArgs := os.Args
+	os.Args = []string{
+		"pushover",
+		"sounds",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+	}
+
+	// Nothing to check - exercising code
+	main()
+
+	// Nothing to check - exercising code
+	os.Args[5] = "fail"
+	main()
+
+	// Test no server
+	apiServer.Close()
+	main()
+
+	os.Args = savedArgs
+}
+
+func serverLimitsHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"limit":10000,"remaining":7496,"reset":1393653600,"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverLimitsCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverLimitsHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	// Test valid input and output
+	savedArgs := os.Args
+	baseArgs := []string{
+		"pushover",
+		"limits",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+	}
+	os.Args = baseArgs
+
+	main()
+	if exitCode != 0 {
+		t.Error("Exit status without threshold")
+	}
+
+	// Remaining above threshold
+	os.Args = append(baseArgs, "--warn-below", "1000")
+	main()
+	if exitCode != 0 {
+		t.Error("Exit status above threshold")
+	}
+
+	// Remaining below threshold
+	os.Args = append(baseArgs, "--warn-below", "8000")
+	main()
+	if exitCode != 1 {
+		t.Error("Exit status below threshold")
+	}
+
+	// API error with threshold
+	exitCode = 0
+	os.Args = append(baseArgs, "--warn-below", "1000")
+	os.Args[5] = "fail"
+	main()
+	if exitCode != 1 {
+		t.Error("Exit status on API error")
+	}
+
+	// Test no server
+	exitCode = 0
+	apiServer.Close()
+	main()
+	if exitCode != 1 {
+		t.Error("Exit status with no server")
+	}
+
+	os.Args = savedArgs
+}
+
+func serverGroupHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	path := strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, ".json"), "/groups")
+	switch {
+	case path == "" && r.Method == http.MethodPost:
+		fmt.Fprintf(w, `{"group":"g1","status":1,"request":"%s"}`, id)
+	case path == "":
+		fmt.Fprintf(w, `{"groups":[{"group":"g1","name":"Testing"}],"status":1,"request":"%s"}`, id)
+	case strings.Count(path, "/") == 1:
+		fmt.Fprintf(w, `{"name":"Testing","users":[{"user":"u1","device":"pixel2xl","memo":"on call","disabled":true}],"status":1,"request":"%s"}`, id)
+	default:
+		fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+	}
+}
+
+func TestPushoverGroupCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverGroupHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	savedArgs := os.Args
+	groupURL := apiServer.URL + "/groups"
+
+	// Commands that change the group exit with status 1 on failure
+	for _, test := range []struct {
+		args   []string
+		change bool
+	}{
+		{[]string{"create", "--name", "Testing"}, false},
+		{[]string{"list"}, false},
+		{[]string{"show", "--group", "g1"}, false},
+		{[]string{"rename", "--group", "g1", "--name", "Renamed"}, true},
+		{[]string{"add", "--group", "g1", "--user", "u1", "--device", "pixel2xl", "--memo", "on call"}, true},
+		{[]string{"remove", "--group", "g1", "--user", "u1"}, true},
+		{[]string{"disable", "--group", "g1", "--user", "u1"}, true},
+		{[]string{"enable", "--group", "g1", "--user", "u1"}, true},
+	} {
+		// Test valid input and output
+		os.Args = append([]string{"pushover", "group", "--pushoverurl", groupURL, "--token", "token"}, test.args...)
+
+		exitCode = 0
+		main()
+		if exitCode != 0 {
+			t.Error("Group", test.args[0])
+		}
+
+		os.Args[5] = "fail"
+		main()
+		if test.change && exitCode != 1 {
+			t.Error("Group rejected", test.args[0])
+		}
+	}
+
+	// Test no server
+	apiServer.Close()
+	for _, args := range [][]string{
+		{"create", "--name", "Testing"},
+		{"list"},
+		{"show", "--group", "g1"},
+		{"rename", "--group", "g1", "--name", "Renamed"},
+		{"add", "--group", "g1", "--user", "u1"},
+	} {
+		os.Args = append([]string{"pushover", "group", "--pushoverurl", groupURL, "--token", "token"}, args...)
+		main()
+	}
+
+	os.Args = savedArgs
+}
+
+func TestPushoverGroupSyncCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverGroupHandler))
+	defer apiServer.Close()
+	validateServer := httptest.NewServer(http.HandlerFunc(serverValidateHandler))
+	defer validateServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	dir := t.TempDir()
+	membersFile := filepath.Join(dir, "members.yaml")
+	_ = os.WriteFile(membersFile, []byte(`group: g1
+members:
+  - user: u1
+    device: pixel2xl
+    memo: Alice
+  - user: u2
+    disabled: true
+`), 0600)
+	noGroupFile := filepath.Join(dir, "nogroup.yaml")
+	_ = os.WriteFile(noGroupFile, []byte("members: []\n"), 0600)
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	_ = os.WriteFile(invalidFile, []byte("members: {"), 0600)
+
+	savedArgs := os.Args
+	baseArgs := []string{
+		"pushover", "group", "sync",
+		"--pushoverurl", apiServer.URL + "/groups",
+		"--validateurl", validateServer.URL,
+		"--token", "token",
+	}
+
+	// Dry run
+	os.Args = append(baseArgs, "--file", membersFile, "--dry-run")
+	main()
+	if exitCode != 0 {
+		t.Error("Dry run")
+	}
+
+	// Apply
+	os.Args = append(baseArgs, "--file", membersFile)
+	main()
+	if exitCode != 0 {
+		t.Error("Apply")
+	}
+
+	// Invalid token
+	os.Args = append(baseArgs, "--file", membersFile)
+	os.Args[8] = "fail"
+	main()
+	if exitCode != 1 {
+		t.Error("Invalid token")
+	}
+
+	// Missing group
+	exitCode = 0
+	os.Args = append(baseArgs, "--file", noGroupFile)
+	main()
+	if exitCode != 1 {
+		t.Error("Missing group")
+	}
+
+	// Group option with no members
+	exitCode = 0
+	os.Args = append(baseArgs, "--file", noGroupFile, "--group", "g1")
+	main()
+	if exitCode != 0 {
+		t.Error("Group option")
+	}
+
+	// Invalid file
+	os.Args = append(baseArgs, "--file", invalidFile)
+	main()
+	if exitCode != 1 {
+		t.Error("Invalid file")
+	}
+
+	// Missing file
+	exitCode = 0
+	os.Args = append(baseArgs, "--file", filepath.Join(dir, "missing.yaml"))
+	main()
+	if exitCode != 1 {
+		t.Error("Missing file")
+	}
+
+	// Validate failure
+	exitCode = 0
+	validateServer.Close()
+	os.Args = append(baseArgs, "--file", membersFile)
+	main()
+	if exitCode != 1 {
+		t.Error("No validate server")
+	}
+
+	// Not validated without a validate URL for the groups URL
+	exitCode = 0
+	os.Args = []string{
+		"pushover", "group", "sync",
+		"--pushoverurl", apiServer.URL + "/groups",
+		"--token", "token",
+		"--file", membersFile,
+	}
+	main()
+	if exitCode != 0 {
+		t.Error("Groups URL without validate URL")
+	}
+
+	os.Args = savedArgs
+}
+
+func serverGlanceHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverGlanceCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverGlanceHandler))
+	defer apiServer.Close()
+
+	// Test valid input and output
+	savedArgs := os.Args
+	os.Args = []string{
+		"pushover",
+		"glance",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+		"--user", "user",
+		"--title", "Builds",
+		"--text", "3 queued",
+		"--subtext", "2 running",
+		"--count", "3",
+		"--percent", "42",
+	}
+
+	// Nothing to check - exercising code
+	main()
+
+	// Nothing to check - exercising code
+	os.Args[5] = "fail"
+	main()
+
+	// Test no server
+	apiServer.Close()
+	main()
+
+	os.Args = savedArgs
+}
+
+func TestParseGlanceOutput(t *testing.T) {
+	// Plain number
+	v, e := parseGlanceOutput([]byte(" 41.6\n"), optionCount)
+	if e != nil || v.Count == nil || *v.Count != 42 || v.Percent != nil {
+		t.Error("Plain number count")
+	}
+
+	v, e = parseGlanceOutput([]byte("75"), optionPercent)
+	if e != nil || v.Percent == nil || *v.Percent != 75 || v.Count != nil {
+		t.Error("Plain number percent")
+	}
+
+	// JSON
+	v, e = parseGlanceOutput([]byte(`{"title":"Builds","text":"3 queued","count":3,"percent":0}`), optionCount)
+	if e != nil || v.Title != "Builds" || v.Text != "3 queued" || v.Subtext != "" ||
+		v.Count == nil || *v.Count != 3 || v.Percent == nil || *v.Percent != 0 {
+		t.Error("JSON output")
+	}
+
+	// Invalid output
+	for _, output := range []string{"", "three", `{"count":"3"}`, `{"count":3`} {
+		if _, e = parseGlanceOutput([]byte(output), optionCount); e == nil {
+			t.Errorf("Invalid output %q", output)
+		}
+	}
+
+	if _, e = parseGlanceOutput([]byte("3"), "total"); e == nil {
+		t.Error("Invalid number field")
+	}
+}
+
+func TestWatchGlance(t *testing.T) {
+	var updates int32
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&updates, 1)
+		serverGlanceHandler(w, r)
+	}))
+	defer apiServer.Close()
+
+	savedInterval := minGlanceUpdateInterval
+	minGlanceUpdateInterval = 0
+	defer func() { minGlanceUpdateInterval = savedInterval }()
+
+	request := pushover.GlanceRequest{
+		PushoverURL: apiServer.URL,
+		Token:       "token",
+		User:        "user",
+	}
+
+	watch := func(command string) int32 {
+		atomic.StoreInt32(&updates, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		watchGlance(ctx, request, command, optionCount, 10*time.Millisecond)
+		return atomic.LoadInt32(&updates)
+	}
+
+	// Unchanged output is only sent once
+	if n := watch("echo 42"); n != 1 {
+		t.Errorf("Unchanged output sent %d times", n)
+	}
+
+	if n := watch(`echo '{"title":"Builds","count":3}'`); n != 1 {
+		t.Errorf("Unchanged JSON output sent %d times", n)
+	}
+
+	// Changed output is sent each time
+	if n := watch("date +%s%N"); n < 2 {
+		t.Errorf("Changed output sent %d times", n)
+	}
+
+	// Changed output is held by the update interval
+	minGlanceUpdateInterval = time.Hour
+	if n := watch("date +%s%N"); n != 1 {
+		t.Errorf("Rate limited output sent %d times", n)
+	}
+	minGlanceUpdateInterval = 0
+
+	// Failed commands and invalid output are not sent
+	if n := watch("exit 1"); n != 0 {
+		t.Errorf("Failed command sent %d times", n)
+	}
+
+	if n := watch("echo three"); n != 0 {
+		t.Errorf("Invalid output sent %d times", n)
+	}
+
+	// Rejected updates are retried after the update interval
+	request.Token = "fail"
+	if n := watch("echo 42"); n < 2 {
+		t.Errorf("Rejected update sent %d times", n)
+	}
+
+	// No server
+	apiServer.Close()
+	if n := watch("echo 42"); n != 0 {
+		t.Errorf("No server sent %d times", n)
+	}
+}
+
+func TestPushoverGlanceWatchCLI(t *testing.T) {
+	savedArgs := os.Args
+	os.Args = []string{
+		"pushover",
+		"glance",
+		"watch",
+		"--token", "token",
+		"--user", "user",
+		"--command", "echo 42",
+		"--interval", "0s",
+	}
+
+	// Nothing to check - exercising code
+	main()
+
+	os.Args = savedArgs
+}
+
+func serverSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Form.Get("token") == "fail" || r.Form.Get("user") == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"subscribed_user_key":"sub-%s","status":1,"request":"%s"}`, r.Form.Get("user"), id)
+}
+
+func TestPushoverSubscriptionCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverSubscriptionHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	dir := t.TempDir()
+	usersFile := filepath.Join(dir, "users.csv")
+	_ = os.WriteFile(usersFile, []byte("user,device,sound\nu1,pixel2xl,siren\n# comment\nu2\n"), 0600)
+	failFile := filepath.Join(dir, "fail.csv")
+	_ = os.WriteFile(failFile, []byte("u1\nfail\n"), 0600)
+	invalidFile := filepath.Join(dir, "invalid.csv")
+	_ = os.WriteFile(invalidFile, []byte("u1\n,pixel2xl\n"), 0600)
+
+	savedArgs := os.Args
+	baseArgs := []string{
+		"pushover", "subscription", "migrate",
+		"--pushoverurl", apiServer.URL,
+		"--token", "token",
+		"--subscription", "subscription",
+	}
+
+	// Single user
+	os.Args = append(baseArgs, "--user", "u1", "--device", "pixel2xl", "--sound", "siren")
+	main()
+	if exitCode != 0 {
+		t.Error("Single user")
+	}
+
+	// Users file
+	os.Args = append(baseArgs, "--file", usersFile)
+	main()
+	if exitCode != 0 {
+		t.Error("Users file")
+	}
+
+	// Users file with failures
+	os.Args = append(baseArgs, "--file", failFile)
+	main()
+	if exitCode != 1 {
+		t.Error("Users file with failures")
+	}
+
+	// Invalid users file
+	exitCode = 0
+	os.Args = append(baseArgs, "--file", invalidFile)
+	main()
+	if exitCode != 1 {
+		t.Error("Invalid users file")
+	}
+
+	// Missing users file
+	exitCode = 0
+	os.Args = append(baseArgs, "--file", filepath.Join(dir, "missing.csv"))
+	main()
+	if exitCode != 1 {
+		t.Error("Missing users file")
+	}
+
+	// No user or file
+	exitCode = 0
+	os.Args = baseArgs
+	main()
+	if exitCode != 1 {
+		t.Error("No user or file")
+	}
+
+	// Test no server
+	exitCode = 0
+	apiServer.Close()
+	os.Args = append(baseArgs, "--user", "u1")
+	main()
+	os.Args = append(baseArgs, "--file", usersFile)
+	main()
+	if exitCode != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func TestReadSubscriptionFile(t *testing.T) {
+	dir := t.TempDir()
+	usersFile := filepath.Join(dir, "users.csv")
+	_ = os.WriteFile(usersFile, []byte("User,Device,Sound\nu1, pixel2xl, siren\nu2\nu3,,bike\n"), 0600)
+
+	requests, err := readSubscriptionFile(usersFile)
+	if err != nil || len(requests) != 3 ||
+		requests[0].User != "u1" || requests[0].DeviceName != "pixel2xl" || requests[0].Sound != "siren" ||
+		requests[1].User != "u2" || requests[1].DeviceName != "" ||
+		requests[2].User != "u3" || requests[2].DeviceName != "" || requests[2].Sound != "bike" {
+		t.Error("Users file")
+	}
+
+	quotedFile := filepath.Join(dir, "quoted.csv")
+	_ = os.WriteFile(quotedFile, []byte("u1\n\"u2\n"), 0600)
+	if _, err = readSubscriptionFile(quotedFile); err == nil {
+		t.Error("Invalid CSV")
+	}
+}
+
+func serverLicenseHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	token := r.Form["token"]
+	if len(token) > 0 && token[0] == "fail" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"credits":5,"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverLicenseCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverLicenseHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	// Assignments exit with status 1 on failure
+	savedArgs := os.Args
+	for _, test := range []struct {
+		args   []string
+		assign bool
+	}{
+		{[]string{"credits"}, false},
+		{[]string{"assign", "--user", "user", "--os", "Android"}, true},
+		{[]string{"assign", "--email", "user@example.com"}, true},
+	} {
+		os.Args = append([]string{
+			"pushover", "license",
+			"--pushoverurl", apiServer.URL + "/licenses",
+			"--token", "token",
+		}, test.args...)
+
+		exitCode = 0
+		main()
+		if exitCode != 0 {
+			t.Error("License", test.args)
+		}
+
+		os.Args[5] = "fail"
+		main()
+		if test.assign && exitCode != 1 {
+			t.Error("License rejected", test.args)
+		}
+	}
+
+	// Missing user and email
+	exitCode = 0
+	os.Args = []string{"pushover", "license", "assign", "--pushoverurl", apiServer.URL + "/licenses", "--token", "token"}
+	main()
+	if exitCode != 1 {
+		t.Error("Missing user and email")
+	}
+
+	// Test no server
+	apiServer.Close()
+	os.Args = []string{"pushover", "license", "credits", "--pushoverurl", apiServer.URL, "--token", "token"}
+	main()
+	exitCode = 0
+	os.Args = []string{"pushover", "license", "assign", "--pushoverurl", apiServer.URL, "--token", "token", "--user", "user"}
+	main()
+	if exitCode != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func TestPushoverTeamCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverCancelHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	savedArgs := os.Args
+	for _, args := range [][]string{
+		{"add", "--email", "user@example.com", "--name", "Alice", "--password", "secret",
+			"--instant", "--admin", "--group", "Operations"},
+		{"remove", "--email", "user@example.com"},
+	} {
+		os.Args = append([]string{
+			"pushover", "team",
+			"--pushoverurl", apiServer.URL,
+			"--token", "token",
+		}, args...)
+
+		exitCode = 0
+		main()
+		if exitCode != 0 {
+			t.Error("Team", args[0])
+		}
+
+		os.Args[5] = "fail"
+		main()
+		if exitCode != 1 {
+			t.Error("Team rejected", args[0])
+		}
+	}
+
+	// Test no server
+	apiServer.Close()
+	for _, command := range []string{"add", "remove"} {
+		exitCode = 0
+		os.Args = []string{"pushover", "team", command, "--pushoverurl", apiServer.URL, "--token", "token", "--email", "user@example.com"}
+		main()
+		if exitCode != 1 {
+			t.Error("No server", command)
+		}
+	}
+
+	os.Args = savedArgs
+}
+
+func serverClientHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/users/login.json"):
+		switch {
+		case r.Form.Get("password") != "password":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"errors":["invalid email and/or password"],"status":0,"request":"%s"}`, id)
+		case r.Form.Get("email") == "twofa@example.com" && r.Form.Get("twofa") != "123456":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprintf(w, `{"errors":["two-factor authentication code required"],"status":0,"request":"%s"}`, id)
+		default:
+			fmt.Fprintf(w, `{"status":1,"id":"user","secret":"secret","request":"%s"}`, id)
+		}
+	case strings.HasSuffix(r.URL.Path, "/devices.json"):
+		if r.Form.Get("name") == "taken" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"errors":{"name":["has already been taken"]},"status":0,"request":"%s"}`, id)
+			return
+		}
+		fmt.Fprintf(w, `{"status":1,"id":"device","request":"%s"}`, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPushoverClientRegisterCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverClientHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	stateFile := filepath.Join(t.TempDir(), "pushover", "client.json")
+
+	savedArgs := os.Args
+	baseArgs := []string{
+		"pushover", "client", "register",
+		"--pushoverurl", apiServer.URL,
+		"--state", stateFile,
+	}
+
+	t.Setenv(envPassword, "password")
+
+	// Valid registration
+	os.Args = append(baseArgs, "--email", "user@example.com", "--name", "workstation")
+	main()
+	s, err := readClientState(stateFile)
+	if exitCode != 0 || err != nil || s.User != "user" || s.Secret != "secret" ||
+		s.DeviceID != "device" || s.DeviceName != "workstation" {
+		t.Error("Valid registration")
+	}
+
+	// Two-factor code
+	os.Args = append(baseArgs, "--email", "twofa@example.com", "--name", "workstation")
+	main()
+	if exitCode != 1 {
+		t.Error("Two-factor code required")
+	}
+
+	exitCode = 0
+	os.Args = append(baseArgs, "--email", "twofa@example.com", "--name", "workstation", "--twofa", "123456")
+	main()
+	if exitCode != 0 {
+		t.Error("Two-factor code supplied")
+	}
+
+	// Failures
+	for _, args := range [][]string{
+		{"--email", "user@example.com", "--name", "work station"},
+		{"--email", "user@example.com", "--name", "taken"},
+		{"--email", "user@example.com", "--name", "workstation", "--password", "wrong"},
+		{"--email", "user@example.com", "--name", "workstation", "--state", t.TempDir()},
+	} {
+		exitCode = 0
+		os.Args = append(baseArgs, args...)
+		main()
+		if exitCode != 1 {
+			t.Errorf("Failure %v", args)
+		}
+	}
+
+	// Password read from stdin
+	os.Unsetenv(envPassword)
+	savedStdin := os.Stdin
+	defer func() { os.Stdin = savedStdin }()
+	for input, code := range map[string]int{"password\n": 0, "password": 0, "wrong\n": 1, "": 1} {
+		stdinFile := filepath.Join(t.TempDir(), "stdin")
+		_ = os.WriteFile(stdinFile, []byte(input), 0600)
+		os.Stdin, _ = os.Open(stdinFile)
+
+		exitCode = 0
+		os.Args = append(baseArgs, "--email", "user@example.com", "--name", "workstation")
+		main()
+		os.Stdin.Close()
+		if exitCode != code {
+			t.Errorf("Password from stdin %q", input)
+		}
+	}
+
+	// Password option overrides stdin
+	os.Stdin = savedStdin
+	exitCode = 0
+	os.Args = append(baseArgs, "--email", "user@example.com", "--name", "workstation", "--password", "password")
+	main()
+	if exitCode != 0 {
+		t.Error("Password option")
+	}
+
+	// Test no server
+	exitCode = 0
+	apiServer.Close()
+	os.Args = append(baseArgs, "--email", "user@example.com", "--name", "workstation", "--password", "password")
+	main()
+	if exitCode != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func TestClientState(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := readClientState(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Missing state file")
+	}
+
+	invalidFile := filepath.Join(dir, "invalid.json")
+	_ = os.WriteFile(invalidFile, []byte("{"), 0600)
+	if _, err := readClientState(invalidFile); err == nil {
+		t.Error("Invalid state file")
+	}
+
+	emptyFile := filepath.Join(dir, "empty.json")
+	_ = os.WriteFile(emptyFile, []byte("{}"), 0600)
+	if _, err := readClientState(emptyFile); err == nil {
+		t.Error("Unregistered state file")
+	}
+
+	if len(defaultClientStateFile()) == 0 {
+		t.Error("Default state file")
+	}
+}
+
+func serverInboxHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Form.Get("secret") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/messages.json"):
+		if r.Form.Get("device_id") == "empty" {
+			fmt.Fprintf(w, `{"messages":[],"status":1,"request":"%s"}`, id)
+			return
+		}
+		fmt.Fprintf(w, `{"messages":[{"id":3,"umid":1234,"title":"Backup","message":"Backup complete","app":"Backups","aid":42,"icon":"backups","date":1360019238,"priority":0,"acked":0,"url":"https://example.com","html":0},{"id":5,"umid":1235,"message":"Disk full","app":"Monitor","aid":43,"icon":"monitor","date":1360019300,"priority":2,"acked":0,"html":0,"receipt":"receipt"}],"status":1,"request":"%s"}`, id)
+	case strings.HasSuffix(r.URL.Path, "/acknowledge.json"):
+		if strings.Contains(r.URL.Path, "/receipt/") {
+			fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"%s"}`, id)
+	case strings.HasSuffix(r.URL.Path, "/update_highest_message.json"):
+		if strings.Contains(r.URL.Path, "/faildelete/") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"message":"invalid","errors":["message is invalid"],"status":0,"request":"%s"}`, id)
+			return
+		}
+		fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writeTestClientState(t *testing.T, secret, deviceID string) string {
+	stateFile := filepath.Join(t.TempDir(), "client.json")
+	if err := writeClientState(stateFile, clientState{User: "user", Secret: secret, DeviceID: deviceID}); err != nil {
+		t.Fatal(err)
+	}
+
+	return stateFile
+}
+
+func TestPushoverInboxCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverInboxHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	savedArgs := os.Args
+	run := func(stateFile string, args ...string) int {
+		exitCode = 0
+		os.Args = append([]string{"pushover", "inbox", "--pushoverurl", apiServer.URL, "--state", stateFile}, args...)
+		main()
+		return exitCode
+	}
+
+	stateFile := writeTestClientState(t, "secret", "device")
+
+	// Text, JSON and clear
+	for _, args := range [][]string{{}, {"--json"}, {"--clear"}, {"--json", "--clear"}} {
+		if code := run(stateFile, args...); code != 0 {
+			t.Errorf("Inbox %v", args)
+		}
+	}
+
+	// No messages to clear
+	if code := run(writeTestClientState(t, "secret", "empty"), "--clear"); code != 0 {
+		t.Error("No messages")
+	}
+
+	// Failures
+	if code := run(writeTestClientState(t, "secret", "faildelete"), "--clear"); code != 1 {
+		t.Error("Clear rejected")
+	}
+
+	if code := run(writeTestClientState(t, "invalid", "device")); code != 1 {
+		t.Error("Invalid secret")
+	}
+
+	if code := run(filepath.Join(t.TempDir(), "missing.json")); code != 1 {
+		t.Error("Missing state file")
+	}
+
+	// Test no server
+	apiServer.Close()
+	if code := run(stateFile); code != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func TestPushoverClientForwardCLI(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", serverInboxHandler)
+	mux.Handle("/push", websocket.Handler(func(ws *websocket.Conn) {
+		buf := make([]byte, 128)
+		if _, err := ws.Read(buf); err != nil {
+			return
+		}
+		_ = websocket.Message.Send(ws, []byte("#!A"))
+		_, _ = ws.Read(buf)
+	}))
+	apiServer := httptest.NewServer(mux)
+	defer apiServer.Close()
+	webSocketURL := "ws" + strings.TrimPrefix(apiServer.URL, "http") + "/push"
+
+	var posted []string
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(strings.Builder)
+		_, _ = io.Copy(body, r.Body)
+		posted = append(posted, body.String())
+		if strings.Contains(body.String(), `"id":5`) && r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer webhookServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	savedArgs := os.Args
+	run := func(stateFile string, args ...string) int {
+		exitCode = 0
+		os.Args = append([]string{"pushover", "client", "forward", "--pushoverurl", apiServer.URL, "--websocketurl", webSocketURL, "--state", stateFile}, args...)
+		main()
+		return exitCode
+	}
+
+	stateFile := writeTestClientState(t, "secret", "device")
+
+	// Command
+	outputFile := filepath.Join(t.TempDir(), "messages.json")
+	if code := run(stateFile, "--once", "--command", "cat >> "+outputFile+"; echo >> "+outputFile); code != 0 {
+		t.Error("Command")
+	}
+	data, _ := os.ReadFile(outputFile)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"id":3`) || !strings.Contains(lines[0], `"title":"Backup"`) ||
+		!strings.Contains(lines[1], `"id":5`) {
+		t.Error("Command input", lines)
+	}
+
+	// Webhook
+	if code := run(stateFile, "--once", "--webhook", webhookServer.URL); code != 0 || len(posted) != 2 {
+		t.Error("Webhook")
+	}
+
+	// Listen until another session logs in
+	posted = nil
+	if code := run(stateFile, "--webhook", webhookServer.URL); code != 1 || len(posted) != 2 {
+		t.Error("Listen", posted)
+	}
+
+	// Failures
+	for _, args := range [][]string{
+		{"--once", "--command", "exit 1"},
+		{"--once", "--webhook", webhookServer.URL + "/fail"},
+		{"--once", "--webhook", "http://127.0.0.1:0"},
+		{"--once", "--webhook", "://invalid"},
+		{"--webhook", webhookServer.URL + "/fail"},
+		{},
+	} {
+		if code := run(stateFile, args...); code != 1 {
+			t.Errorf("Failure %v", args)
+		}
+	}
+
+	if code := run(writeTestClientState(t, "secret", "faildelete"), "--once", "--webhook", webhookServer.URL); code != 1 {
+		t.Error("Delete rejected")
+	}
+
+	if code := run(writeTestClientState(t, "invalid", "device"), "--once", "--webhook", webhookServer.URL); code != 1 {
+		t.Error("Invalid secret")
+	}
+
+	if code := run(filepath.Join(t.TempDir(), "missing.json"), "--once", "--webhook", webhookServer.URL); code != 1 {
+		t.Error("Missing state file")
+	}
+
+	// Test no server
+	apiServer.Close()
+	if code := run(stateFile, "--once", "--webhook", webhookServer.URL); code != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
+
+func TestPushoverClientAckCLI(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverInboxHandler))
+	defer apiServer.Close()
+
+	exitCode := 0
+	osExit = func(code int) { exitCode = code }
+	defer func() { osExit = os.Exit }()
+
+	savedArgs := os.Args
+	run := func(stateFile string, args ...string) int {
+		exitCode = 0
+		os.Args = append([]string{"pushover", "client", "ack", "--pushoverurl", apiServer.URL, "--state", stateFile}, args...)
+		main()
+		return exitCode
+	}
+
+	stateFile := writeTestClientState(t, "secret", "device")
+
+	// Valid acknowledgement
+	if code := run(stateFile, "receipt"); code != 0 {
+		t.Error("Valid acknowledgement")
+	}
+
+	// Failures
+	if code := run(stateFile, "unknown"); code != 1 {
+		t.Error("Unknown receipt")
+	}
+
+	if code := run(filepath.Join(t.TempDir(), "missing.json"), "receipt"); code != 1 {
+		t.Error("Missing state file")
+	}
+
+	// Test no server
+	apiServer.Close()
+	if code := run(stateFile, "receipt"); code != 1 {
+		t.Error("No server")
+	}
+
+	os.Args = savedArgs
+}
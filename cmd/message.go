@@ -35,6 +35,7 @@ func outputMessageRequest(r pushover.MessageRequest) {
 		{field: "Retry", value: r.Retry},
 		{field: "Expire", value: r.Expire},
 		{field: "Timestamp", value: r.Timestamp},
+		{field: "Tags", value: r.Tags},
 	}
 
 	for _, i := range fields {
@@ -56,28 +57,13 @@ func outputMessageResponse(r pushover.MessageResponse) {
 		fmt.Printf("%-*s %s\n", maxLen, "Receipt:", r.Receipt)
 	}
 
-	if len(r.ErrorParameters) > 0 {
-		maxLen := 0
-		for k := range r.ErrorParameters {
-			curLen := len(k)
-			if curLen > maxLen {
-				maxLen = curLen
-			}
-		}
-		maxLen++
-
-		fmt.Println("Parameter Errors:")
-		for k, v := range r.ErrorParameters {
-			fmt.Printf("  %-*s %s\n", maxLen, k+":", v)
-		}
+	if r.AppLimit > 0 {
+		fmt.Printf("%-*s %d\n", maxLen, "App Limit:", r.AppLimit)
+		fmt.Printf("%-*s %d\n", maxLen, "App Remaining:", r.AppRemaining)
+		fmt.Printf("%-*s %s\n", maxLen, "App Reset:", timeToString(r.AppReset))
 	}
 
-	if len(r.Errors) > 0 {
-		fmt.Println("Errors:")
-		for _, v := range r.Errors {
-			fmt.Println(" ", v)
-		}
-	}
+	outputErrors(r.Errors, r.ErrorParameters)
 
 	fmt.Println("Response Body:", r.ResponseBody)
 }
@@ -95,7 +81,7 @@ func intOptionToString(cmd *cobra.Command, option string, value int) string {
 func addMessageCmd(parentCmd *cobra.Command) {
 	const enable = "1"
 	var token, user, title, message, url, urlTitle, sound, device, image,
-		timestamp, pushoverURL, htmlField, monospaceValue, callback string
+		timestamp, pushoverURL, htmlField, monospaceValue, callback, tags string
 	var priority int8
 	var retry, expire int16
 	var html, monospace bool
@@ -153,6 +139,7 @@ Required options are:
 				ImageReader: imageReader,
 				ImageName:   image,
 				Callback:    callback,
+				Tags:        tags,
 			}
 
 			fmt.Println("Request")
@@ -164,7 +151,7 @@ Required options are:
 			fmt.Println()
 			fmt.Println("Response")
 
-			if e == nil {
+			if r != nil {
 				outputMessageResponse(*r)
 			} else {
 				fmt.Println(e)
@@ -195,6 +182,7 @@ Required options are:
 	messageCmd.Flags().Int16VarP(&expire, optionExpire, "", 0, "Message expiration length")
 	messageCmd.Flags().StringVarP(&timestamp, optionTimestamp, "", "", "Unix timestamp for message")
 	messageCmd.Flags().StringVarP(&callback, optionCallback, "", "", "Optional callback URL")
+	messageCmd.Flags().StringVarP(&tags, optionTags, "", "", "Comma separated tags for cancelling emergency messages")
 
 	parentCmd.AddCommand(messageCmd)
 }
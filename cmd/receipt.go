@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var receiptCmd *cobra.Command
+
+func outputReceiptRequest(r pushover.ReceiptRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Receipt", value: r.Receipt},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputReceiptResponse(r pushover.ReceiptResponse) {
+	deviceText := "Acknowledged By Device:"
+	maxLen := len(deviceText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, "HTML Status Code:", r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	if r.APIStatus == 1 {
+		fmt.Printf("%-*s %t\n", maxLen, "Acknowledged:", r.Acknowledged)
+		fmt.Printf("%-*s %s\n", maxLen, "Acknowledged At:", timeToString(r.AcknowledgedAt))
+		fmt.Printf("%-*s %s\n", maxLen, "Acknowledged By:", r.AcknowledgedBy)
+		fmt.Printf("%-*s %s\n", maxLen, deviceText, r.AcknowledgedByDevice)
+		fmt.Printf("%-*s %s\n", maxLen, "Last Delivered At:", timeToString(r.LastDeliveredAt))
+		fmt.Printf("%-*s %t\n", maxLen, "Expired:", r.Expired)
+		fmt.Printf("%-*s %s\n", maxLen, "Expires At:", timeToString(r.ExpiresAt))
+		fmt.Printf("%-*s %t\n", maxLen, "Called Back:", r.CalledBack)
+		fmt.Printf("%-*s %s\n", maxLen, "Called Back At:", timeToString(r.CalledBackAt))
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addReceiptCmd(parentCmd *cobra.Command) {
+	var token, receipt, pushoverURL string
+
+	receiptCmd = &cobra.Command{
+		Use:   "receipt",
+		Short: "Submit a receipt request",
+		Long: `Retrieve the status of an emergency (priority 2)
+notification, including whether it has been acknowledged.
+
+Required options are:
+  --token
+  --receipt
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.ReceiptRequest{
+				PushoverURL: pushoverURL,
+				Token:       token,
+				Receipt:     receipt,
+			}
+
+			fmt.Println("Request")
+
+			outputReceiptRequest(request)
+
+			r, e := pushover.Receipt(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputReceiptResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	receiptCmd.Flags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = receiptCmd.MarkFlagRequired(optionToken)
+	receiptCmd.Flags().StringVarP(&receipt, optionReceipt, "r", "", "Receipt from an emergency priority message")
+	_ = receiptCmd.MarkFlagRequired(optionReceipt)
+
+	// Optional options
+	receiptCmd.Flags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	parentCmd.AddCommand(receiptCmd)
+}
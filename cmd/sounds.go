@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var soundsCmd *cobra.Command
+
+func outputSoundsRequest(r pushover.SoundsRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputSoundsResponse(r pushover.SoundsResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	names := make([]string, 0, len(r.Sounds))
+	nameLen := 0
+	for k := range r.Sounds {
+		names = append(names, k)
+		if len(k) > nameLen {
+			nameLen = len(k)
+		}
+	}
+	nameLen++
+	sort.Strings(names)
+
+	fmt.Println("Sounds:")
+	for _, v := range names {
+		fmt.Printf("  %-*s %s\n", nameLen, v+":", r.Sounds[v])
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addSoundsCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+
+	soundsCmd = &cobra.Command{
+		Use:   "sounds",
+		Short: "Submit a sounds request",
+		Long: `List the sounds available to an application. The
+sound names are the values accepted by the --sound option
+of the message command.
+
+Required options are:
+  --token
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.SoundsRequest{
+				PushoverURL: pushoverURL,
+				Token:       token,
+			}
+
+			fmt.Println("Request")
+
+			outputSoundsRequest(request)
+
+			r, e := pushover.Sounds(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputSoundsResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	soundsCmd.Flags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = soundsCmd.MarkFlagRequired(optionToken)
+
+	// Optional options
+	soundsCmd.Flags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	parentCmd.AddCommand(soundsCmd)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var subscriptionCmd *cobra.Command
+
+func outputMigrateSubscriptionRequest(r pushover.MigrateSubscriptionRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Subscription", value: r.Subscription},
+		{field: "User", value: r.User},
+		{field: "Device", value: r.DeviceName},
+		{field: "Sound", value: r.Sound},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputMigrateSubscriptionResponse(r pushover.MigrateSubscriptionResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	if len(r.SubscribedUserKey) > 0 {
+		fmt.Printf("%-*s %s\n", maxLen, "Subscribed Key:", r.SubscribedUserKey)
+	}
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+// readSubscriptionFile reads the users to migrate from a CSV
+// file with the columns user, device and sound. Only the user
+// column is required. A header row starting with "user" is
+// skipped.
+func readSubscriptionFile(name string) ([]pushover.MigrateSubscriptionRequest, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	requests := []pushover.MigrateSubscriptionRequest{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if line == 1 && strings.EqualFold(record[0], optionUser) {
+			continue
+		}
+
+		if len(record[0]) == 0 {
+			return nil, fmt.Errorf("record on line %d has no user", line)
+		}
+
+		request := pushover.MigrateSubscriptionRequest{User: record[0]}
+		if len(record) > 1 {
+			request.DeviceName = record[1]
+		}
+		if len(record) > 2 {
+			request.Sound = record[2]
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// migrateSubscriptionFile migrates the users in a CSV file and
+// writes a CSV of the users and their subscription keys to w. It
+// returns false if any of the users could not be migrated.
+func migrateSubscriptionFile(w io.Writer, requests []pushover.MigrateSubscriptionRequest) bool {
+	ok := true
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"user", "device", "sound", "subscribed_user_key", "error"})
+
+	for _, request := range requests {
+		var key, message string
+
+		var apiErr *pushover.APIError
+		r, err := pushover.MigrateSubscriptionContext(context.Background(), request)
+		switch {
+		case errors.As(err, &apiErr):
+			message = strings.Join(apiErr.Errors, "; ")
+		case err != nil:
+			message = err.Error()
+		default:
+			key = r.SubscribedUserKey
+		}
+
+		if len(key) == 0 {
+			ok = false
+		}
+
+		_ = writer.Write([]string{request.User, request.DeviceName, request.Sound, key, message})
+		writer.Flush()
+	}
+
+	return ok
+}
+
+func addSubscriptionMigrateCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var subscription, user, device, sound, file string
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Submit a subscription migration request",
+		Long: `Migrate user keys to subscription user keys so the users
+can unsubscribe from the application themselves.
+
+To migrate many users, list them in a CSV file with the
+columns user, device and sound. Only the user column is
+required and a header row is optional:
+
+  user,device,sound
+  uQiRzpo4DXghDmr9QzzfQu27cmVRsG,pixel2xl,siren
+  u7Ks8DHzYS6iMxbmjwLQH9TgWyWfPk
+
+A CSV of the users and their subscription user keys is
+printed. The exit status is 1 if any user could not be
+migrated.
+
+Required options are:
+  --token
+  --subscription
+  --user or --file
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(user) == 0 && len(file) == 0 {
+				fmt.Println("Error: one of --user or --file is required")
+				osExit(1)
+				return
+			}
+
+			if len(file) > 0 {
+				requests, err := readSubscriptionFile(file)
+				if err != nil {
+					fmt.Println("Error reading users file:", err)
+					osExit(1)
+					return
+				}
+
+				for i := range requests {
+					requests[i].PushoverURL = *pushoverURL
+					requests[i].Token = *token
+					requests[i].Subscription = subscription
+				}
+
+				if !migrateSubscriptionFile(os.Stdout, requests) {
+					osExit(1)
+				}
+
+				return
+			}
+
+			request := pushover.MigrateSubscriptionRequest{
+				PushoverURL:  *pushoverURL,
+				Token:        *token,
+				Subscription: subscription,
+				User:         user,
+				DeviceName:   device,
+				Sound:        sound,
+			}
+
+			fmt.Println("Request")
+
+			outputMigrateSubscriptionRequest(request)
+
+			r, e := pushover.MigrateSubscription(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputMigrateSubscriptionResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+		},
+	}
+
+	// Required options
+	migrateCmd.Flags().StringVarP(&subscription, optionSubscription, "s", "", "Subscription code")
+	_ = migrateCmd.MarkFlagRequired(optionSubscription)
+	migrateCmd.Flags().StringVarP(&user, optionUser, "u", "", "User key to migrate")
+	migrateCmd.Flags().StringVarP(&file, optionFile, "f", "", "CSV file of users to migrate")
+	migrateCmd.MarkFlagsMutuallyExclusive(optionUser, optionFile)
+
+	// Optional options
+	migrateCmd.Flags().StringVarP(&device, optionDevice, "", "", "Device name to limit the subscription to")
+	migrateCmd.Flags().StringVarP(&sound, optionSound, "", "", "Sound for the subscription")
+	migrateCmd.MarkFlagsMutuallyExclusive(optionDevice, optionFile)
+	migrateCmd.MarkFlagsMutuallyExclusive(optionSound, optionFile)
+
+	parentCmd.AddCommand(migrateCmd)
+}
+
+func addSubscriptionCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+
+	subscriptionCmd = &cobra.Command{
+		Use:   "subscription",
+		Short: "Submit subscription requests",
+		Long: `Manage the subscriptions of users to an application.
+
+Required options for all subscription commands are:
+  --token
+`,
+	}
+
+	// Required options
+	subscriptionCmd.PersistentFlags().StringVarP(&token, optionToken, "t", "", "Application's API token")
+	_ = subscriptionCmd.MarkPersistentFlagRequired(optionToken)
+
+	// Optional options
+	subscriptionCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	addSubscriptionMigrateCmd(subscriptionCmd, &token, &pushoverURL)
+
+	parentCmd.AddCommand(subscriptionCmd)
+}
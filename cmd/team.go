@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arcanericky/pushover"
+	"github.com/spf13/cobra"
+)
+
+var teamCmd *cobra.Command
+
+func outputAddTeamUserRequest(r pushover.AddTeamUserRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Email", value: r.Email},
+		{field: "Name", value: r.Name},
+		{field: "Group", value: r.Group},
+		{field: "Instant", value: strconv.FormatBool(r.Instant)},
+		{field: "Admin", value: strconv.FormatBool(r.Admin)},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputRemoveTeamUserRequest(r pushover.RemoveTeamUserRequest) {
+	pushoverURLText := "Pushover URL"
+	pushoverURLTextLen := len(pushoverURLText)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: pushoverURLText, value: r.PushoverURL},
+		{field: "Token", value: r.Token},
+		{field: "Email", value: r.Email},
+	}
+
+	for _, i := range fields {
+		if len(i.value) > 0 {
+			fmt.Printf("%-*s %s\n", pushoverURLTextLen, i.field+":", i.value)
+		}
+	}
+}
+
+func outputTeamUpdateResponse(r pushover.TeamUpdateResponse) {
+	statusCodeText := "HTML Status Code:"
+	maxLen := len(statusCodeText)
+	fmt.Printf("%-*s %s\n", maxLen, "HTML Status:", r.HTTPStatus)
+	fmt.Printf("%-*s %d\n", maxLen, statusCodeText, r.HTTPStatusCode)
+	fmt.Printf("%-*s %d\n", maxLen, "API Status:", int(r.APIStatus))
+	fmt.Printf("%-*s %s\n", maxLen, "Request ID:", r.Request)
+
+	outputErrors(r.Errors, r.ErrorParameters)
+
+	fmt.Println("Response Body:", r.ResponseBody)
+}
+
+func addTeamAddCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var email, name, password, group string
+	var instant, admin bool
+
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Submit a team add user request",
+		Long: `Add a user to a team by their email address. If no
+Pushover account exists for the email address, one is
+created.
+
+The exit status is 1 if the user could not be added.
+
+Required options are:
+  --token
+  --email
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.AddTeamUserRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				Email:       email,
+				Name:        name,
+				Password:    password,
+				Instant:     instant,
+				Admin:       admin,
+				Group:       group,
+			}
+
+			fmt.Println("Request")
+
+			outputAddTeamUserRequest(request)
+
+			r, e := pushover.AddTeamUser(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputTeamUpdateResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if e != nil {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	addCmd.Flags().StringVarP(&email, optionEmail, "e", "", "User's email address")
+	_ = addCmd.MarkFlagRequired(optionEmail)
+
+	// Optional options
+	addCmd.Flags().StringVarP(&name, optionName, "", "", "User's name")
+	addCmd.Flags().StringVarP(&password, optionPassword, "", "", "Password for a new account")
+	addCmd.Flags().BoolVarP(&instant, optionInstant, "", false, "Send the welcome email immediately")
+	addCmd.Flags().BoolVarP(&admin, optionAdmin, "", false, "Make the user a team administrator")
+	addCmd.Flags().StringVarP(&group, optionGroup, "", "", "Team delivery group to add the user to")
+
+	parentCmd.AddCommand(addCmd)
+}
+
+func addTeamRemoveCmd(parentCmd *cobra.Command, token, pushoverURL *string) {
+	var email string
+
+	removeCmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Submit a team remove user request",
+		Long: `Remove a user from a team by their email address.
+
+The exit status is 1 if the user could not be removed.
+
+Required options are:
+  --token
+  --email
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := pushover.RemoveTeamUserRequest{
+				PushoverURL: *pushoverURL,
+				Token:       *token,
+				Email:       email,
+			}
+
+			fmt.Println("Request")
+
+			outputRemoveTeamUserRequest(request)
+
+			r, e := pushover.RemoveTeamUser(request)
+
+			fmt.Println()
+			fmt.Println("Response")
+
+			if r != nil {
+				outputTeamUpdateResponse(*r)
+			} else {
+				fmt.Println(e)
+			}
+
+			if e != nil {
+				osExit(1)
+			}
+		},
+	}
+
+	// Required options
+	removeCmd.Flags().StringVarP(&email, optionEmail, "e", "", "User's email address")
+	_ = removeCmd.MarkFlagRequired(optionEmail)
+
+	parentCmd.AddCommand(removeCmd)
+}
+
+func addTeamCmd(parentCmd *cobra.Command) {
+	var token, pushoverURL string
+
+	teamCmd = &cobra.Command{
+		Use:   "team",
+		Short: "Submit team requests",
+		Long: `Add and remove the users of a Pushover team. The token
+is the team's API token from the team's settings page.
+
+Required options for all team commands are:
+  --token
+`,
+	}
+
+	// Required options
+	teamCmd.PersistentFlags().StringVarP(&token, optionToken, "t", "", "Team's API token")
+	_ = teamCmd.MarkPersistentFlagRequired(optionToken)
+
+	// Optional options
+	teamCmd.PersistentFlags().StringVarP(&pushoverURL, optionPushoverURL, "", "", "Pushover API URL")
+
+	addTeamAddCmd(teamCmd, &token, &pushoverURL)
+	addTeamRemoveCmd(teamCmd, &token, &pushoverURL)
+
+	parentCmd.AddCommand(teamCmd)
+}
@@ -48,28 +48,7 @@ func outputValidateResponse(r pushover.ValidateResponse) {
 		fmt.Println(" ", v)
 	}
 
-	if len(r.ErrorParameters) > 0 {
-		maxLen := 0
-		for k := range r.ErrorParameters {
-			curLen := len(k)
-			if curLen > maxLen {
-				maxLen = curLen
-			}
-		}
-		maxLen++
-
-		fmt.Println("Parameter Errors:")
-		for k, v := range r.ErrorParameters {
-			fmt.Printf("  %-*s %s\n", maxLen, k+":", v)
-		}
-	}
-
-	if len(r.Errors) > 0 {
-		fmt.Println("Errors:")
-		for _, v := range r.Errors {
-			fmt.Println(" ", v)
-		}
-	}
+	outputErrors(r.Errors, r.ErrorParameters)
 
 	fmt.Println("Response Body:", r.ResponseBody)
 }
@@ -104,7 +83,7 @@ Required options are:
 			fmt.Println()
 			fmt.Println("Response")
 
-			if e == nil {
+			if r != nil {
 				outputValidateResponse(*r)
 			} else {
 				fmt.Println(e)
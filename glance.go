@@ -0,0 +1,178 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+)
+
+// GlanceRequest is the data for the POST to the Pushover
+// Glances API. Some fields in this request should contain
+// numbers but the Pushover API parameters are strings.
+// There is no validation performed for these fields. If
+// invalid data is submitted to the Pushover API, it will
+// be rejected with an appropriate error.
+//
+// At least one of the optional fields must be set. See the
+// Pushover Glances API documentation for more information on
+// these parameters.
+type GlanceRequest struct {
+	// The URL for the Pushover REST API POST.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// The user's token for the glance update
+	User string
+
+	// Optional Fields
+
+	// The device to update rather than all the user's
+	// devices
+	Device string
+
+	// Description of the data being shown, up to 100
+	// characters
+	Title string
+
+	// Main line of data, up to 100 characters
+	Text string
+
+	// Second line of data, up to 100 characters
+	Subtext string
+
+	// Integer shown on smaller screens
+	Count string
+
+	// Integer percentage, from 0 to 100, shown as a progress
+	// bar or circle
+	Percent string
+}
+
+// GlanceResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type GlanceResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// GlanceContext will submit a POST request to the Pushover
+// Glances API. This function will update the data shown on a
+// user's watch face or widget without sending a notification.
+//
+//	  resp, err := pushover.GlanceContext(context.Background(),
+//	    pushover.GlanceRequest{
+//		     Token:   token,
+//		     User:    user,
+//		     Percent: "42",
+//	  })
+func GlanceContext(ctx context.Context, request GlanceRequest) (*GlanceResponse, error) {
+	return defaultClient.GlanceContext(ctx, request)
+}
+
+// GlanceContext will submit a POST request to the Pushover
+// Glances API with the client's settings. This function will
+// update the data shown on a user's watch face or widget
+// without sending a notification.
+//
+//	  resp, err := client.GlanceContext(context.Background(),
+//	    pushover.GlanceRequest{
+//		     User:    user,
+//		     Percent: "42",
+//	  })
+func (c *Client) GlanceContext(ctx context.Context, request GlanceRequest) (*GlanceResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, glancesURL, glancesPath)
+	request.Token = c.token(request.Token)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: keyToken, value: request.Token},
+		{field: keyUser, value: request.User},
+		{field: keyDevice, value: request.Device},
+		{field: keyTitle, value: request.Title},
+		{field: keyText, value: request.Text},
+		{field: keySubtext, value: request.Subtext},
+		{field: keyCount, value: request.Count},
+		{field: keyPercent, value: request.Percent},
+	}
+
+	formData := url.Values{}
+	for _, v := range fields {
+		if len(v.value) > 0 {
+			formData.Set(v.field, v.value)
+		}
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL, formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &GlanceResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// Glance will submit a POST request to the Pushover
+// Glances API. This function will update the data shown on a
+// user's watch face or widget without sending a notification.
+//
+//	  resp, err := pushover.Glance(pushover.GlanceRequest{
+//		     Token:   token,
+//		     User:    user,
+//		     Percent: "42",
+//	  })
+func Glance(request GlanceRequest) (*GlanceResponse, error) {
+	return GlanceContext(context.Background(), request)
+}
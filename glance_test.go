@@ -0,0 +1,143 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+/*
+Glance Valid
+{"status":1,"request":"cd3a6b5e-7c2f-4b3d-9d7b-4f3e2a1c0b9d"}
+
+Glance No Data
+{"errors":["must supply at least one of title, text, subtext, count, or percent"],"status":0,"request":"af0c4d3e-1b2a-4c5d-8e9f-0a1b2c3d4e5f"}
+
+Glance Invalid Percent
+{"percent":"must be between 0 and 100","errors":["percent must be between 0 and 100"],"status":0,"request":"1e2d3c4b-5a69-4788-9766-554433221100"}
+*/
+
+func glanceServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	value := r.Form["token"]
+	if len(value) == 0 || len(value[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check user
+	user := r.Form["user"]
+	if len(user) == 0 || len(user[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if user[0] == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"`, id)
+		return
+	}
+
+	// Check data
+	data := false
+	for _, k := range []string{"title", "text", "subtext", "count", "percent"} {
+		if len(r.Form.Get(k)) > 0 {
+			data = true
+		}
+	}
+	if !data {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"errors":["must supply at least one of title, text, subtext, count, or percent"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check percent
+	if value := r.Form.Get("percent"); len(value) > 0 {
+		if percent, err := strconv.Atoi(value); err != nil || percent < 0 || percent > 100 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"percent":"must be between 0 and 100","errors":["percent must be between 0 and 100"],"status":0,"request":"%s"}`, id)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverGlance(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(glanceServerHandler))
+	defer apiServer.Close()
+
+	var request GlanceRequest
+
+	// Default Pushover URL
+	glancesURL = apiServer.URL
+	r, e := GlanceContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no data
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	request.User = "testuser"
+	r, _ = Glance(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		r.Errors[0] != "must supply at least one of title, text, subtext, count, or percent" {
+		t.Error("Handling of no data")
+	}
+
+	// Invalid percent
+	request.Percent = "101"
+	r, _ = Glance(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		r.ErrorParameters["percent"] != "must be between 0 and 100" {
+		t.Error("Invalid percent")
+	}
+
+	// Load all the fields
+	request.Device = "watch"
+	request.Title = "Builds"
+	request.Text = "3 queued"
+	request.Subtext = "2 running"
+	request.Count = "3"
+	request.Percent = "42"
+	r, e = Glance(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("All fields submitted")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = GlanceContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.User = "failjson"
+	_, e = Glance(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = Glance(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
@@ -5,9 +5,12 @@ go 1.20
 require (
 	github.com/spf13/cobra v1.6.1
 	golang.org/x/net v0.7.0
+	golang.org/x/term v0.10.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.0.1 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/sys v0.10.0 // indirect
 )
@@ -0,0 +1,811 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+)
+
+// Group is a Pushover Delivery Group
+type Group struct {
+	// Group key, used as the User of a MessageRequest to
+	// deliver a message to all members of the group
+	Key string
+
+	// Name of the group
+	Name string
+
+	// Members of the group
+	//
+	// Only populated by GroupInfo
+	Users []GroupMember
+}
+
+// GroupMember is a user in a Pushover Delivery Group
+type GroupMember struct {
+	// The user's key
+	User string
+
+	// The user's device receiving messages for the group
+	//
+	// Empty if all the user's devices receive the messages
+	Device string
+
+	// Free-form note about the user
+	Memo string
+
+	// True if the user is temporarily disabled and not
+	// receiving messages for the group
+	Disabled bool
+}
+
+// CreateGroupRequest is the data for the POST to the Pushover
+// Groups API. See the Pushover Groups API documentation for
+// more information on these parameters.
+type CreateGroupRequest struct {
+	// The base URL for the Pushover Groups API.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Name of the group
+	Name string
+}
+
+// CreateGroupResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type CreateGroupResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Key of the new group
+	Group string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// ListGroupsRequest is the data for the GET to the Pushover
+// Groups API. See the Pushover Groups API documentation for
+// more information on these parameters.
+type ListGroupsRequest struct {
+	// The base URL for the Pushover Groups API.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+}
+
+// ListGroupsResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type ListGroupsResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of groups owned by the account, without their
+	// members
+	Groups []Group
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// GroupInfoRequest is the data for the GET to the Pushover
+// Groups API. See the Pushover Groups API documentation for
+// more information on these parameters.
+type GroupInfoRequest struct {
+	// The base URL for the Pushover Groups API. The group
+	// key is appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Group key
+	Group string
+}
+
+// GroupInfoResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type GroupInfoResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// The group with its members
+	Group Group
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// interfaceArrayToGroups translates the list of groups in a
+// Pushover Groups API response
+func interfaceArrayToGroups(key string, m map[string]interface{}) []Group {
+	groups := []Group{}
+
+	interfaceArray, _ := m[key].([]interface{})
+	for _, v := range interfaceArray {
+		if g, ok := v.(map[string]interface{}); ok {
+			key, _ := g[keyGroup].(string)
+			name, _ := g[keyName].(string)
+			groups = append(groups, Group{Key: key, Name: name})
+		}
+	}
+
+	return groups
+}
+
+// interfaceArrayToGroupMembers translates the list of users
+// in a Pushover Groups API response
+func interfaceArrayToGroupMembers(key string, m map[string]interface{}) []GroupMember {
+	members := []GroupMember{}
+
+	interfaceArray, _ := m[key].([]interface{})
+	for _, v := range interfaceArray {
+		if u, ok := v.(map[string]interface{}); ok {
+			var member GroupMember
+			member.User, _ = u[keyUser].(string)
+			member.Device, _ = u[keyDevice].(string)
+			member.Memo, _ = u[keyMemo].(string)
+			member.Disabled, _ = u[keyDisabled].(bool)
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+// CreateGroupContext will submit a POST request to the Pushover
+// Groups API. This function will create a new, empty group.
+//
+//	  resp, err := pushover.CreateGroupContext(context.Background(),
+//	    pushover.CreateGroupRequest{
+//		     Token: token,
+//		     Name:  name,
+//	  })
+func CreateGroupContext(ctx context.Context, request CreateGroupRequest) (*CreateGroupResponse, error) {
+	return defaultClient.CreateGroupContext(ctx, request)
+}
+
+// CreateGroupContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will create a new, empty group.
+//
+//	  resp, err := client.CreateGroupContext(context.Background(),
+//	    pushover.CreateGroupRequest{
+//		     Name: name,
+//	  })
+func (c *Client) CreateGroupContext(ctx context.Context, request CreateGroupRequest) (*CreateGroupResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, groupsURL, groupsPath)
+	request.Token = c.token(request.Token)
+
+	formData := url.Values{
+		keyToken: {request.Token},
+		keyName:  {request.Name},
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL+".json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CreateGroupResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	var ok bool
+
+	// Populate group key
+	if r.Group, ok = a.Result[keyGroup].(string); ok {
+		delete(a.Result, keyGroup)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// CreateGroup will submit a POST request to the Pushover
+// Groups API. This function will create a new, empty group.
+//
+//	  resp, err := pushover.CreateGroup(pushover.CreateGroupRequest{
+//		     Token: token,
+//		     Name:  name,
+//	  })
+func CreateGroup(request CreateGroupRequest) (*CreateGroupResponse, error) {
+	return CreateGroupContext(context.Background(), request)
+}
+
+// ListGroupsContext will submit a GET request to the Pushover
+// Groups API. This function will retrieve the groups owned by
+// the account.
+//
+//	  resp, err := pushover.ListGroupsContext(context.Background(),
+//	    pushover.ListGroupsRequest{
+//		     Token: token,
+//	  })
+func ListGroupsContext(ctx context.Context, request ListGroupsRequest) (*ListGroupsResponse, error) {
+	return defaultClient.ListGroupsContext(ctx, request)
+}
+
+// ListGroupsContext will submit a GET request to the
+// Pushover Groups API with the client's settings. This
+// function will retrieve the groups owned by the account.
+//
+//	resp, err := client.ListGroupsContext(context.Background(),
+//	  pushover.ListGroupsRequest{})
+func (c *Client) ListGroupsContext(ctx context.Context, request ListGroupsRequest) (*ListGroupsResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, groupsURL, groupsPath)
+	request.Token = c.token(request.Token)
+
+	query := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+".json?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ListGroupsResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate groups
+	r.Groups = interfaceArrayToGroups(keyGroups, a.Result)
+	delete(a.Result, keyGroups)
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// ListGroups will submit a GET request to the Pushover
+// Groups API. This function will retrieve the groups owned by
+// the account.
+//
+//	  resp, err := pushover.ListGroups(pushover.ListGroupsRequest{
+//		     Token: token,
+//	  })
+func ListGroups(request ListGroupsRequest) (*ListGroupsResponse, error) {
+	return ListGroupsContext(context.Background(), request)
+}
+
+// GroupInfoContext will submit a GET request to the Pushover
+// Groups API. This function will retrieve the name and
+// members of a group.
+//
+//	  resp, err := pushover.GroupInfoContext(context.Background(),
+//	    pushover.GroupInfoRequest{
+//		     Token: token,
+//		     Group: group,
+//	  })
+func GroupInfoContext(ctx context.Context, request GroupInfoRequest) (*GroupInfoResponse, error) {
+	return defaultClient.GroupInfoContext(ctx, request)
+}
+
+// GroupInfoContext will submit a GET request to the Pushover
+// Groups API with the client's settings. This function will
+// retrieve the name and members of a group.
+//
+//	  resp, err := client.GroupInfoContext(context.Background(),
+//	    pushover.GroupInfoRequest{
+//		     Group: group,
+//	  })
+func (c *Client) GroupInfoContext(ctx context.Context, request GroupInfoRequest) (*GroupInfoResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, groupsURL, groupsPath)
+	request.Token = c.token(request.Token)
+
+	query := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+"/"+url.PathEscape(request.Group)+".json?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &GroupInfoResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+		Group:          Group{Key: request.Group},
+	}
+
+	var ok bool
+
+	// Populate group name
+	if r.Group.Name, ok = a.Result[keyName].(string); ok {
+		delete(a.Result, keyName)
+	}
+
+	// Populate group members
+	r.Group.Users = interfaceArrayToGroupMembers(keyUsers, a.Result)
+	delete(a.Result, keyUsers)
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// GroupInfo will submit a GET request to the Pushover
+// Groups API. This function will retrieve the name and
+// members of a group.
+//
+//	  resp, err := pushover.GroupInfo(pushover.GroupInfoRequest{
+//		     Token: token,
+//		     Group: group,
+//	  })
+func GroupInfo(request GroupInfoRequest) (*GroupInfoResponse, error) {
+	return GroupInfoContext(context.Background(), request)
+}
+
+// GroupUserRequest is the data for the POST to the Pushover
+// Groups API to change a member of a group. See the Pushover
+// Groups API documentation for more information on these
+// parameters.
+type GroupUserRequest struct {
+	// The base URL for the Pushover Groups API. The group
+	// key and action are appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Group key
+	Group string
+
+	// The user's key
+	User string
+
+	// Optional fields
+
+	// The user's device receiving messages for the group
+	//
+	// When adding a user, leave empty for all the user's
+	// devices to receive the messages
+	Device string
+
+	// Free-form note about the user
+	//
+	// Only used when adding a user
+	Memo string
+}
+
+// RenameGroupRequest is the data for the POST to the Pushover
+// Groups API to rename a group. See the Pushover Groups API
+// documentation for more information on these parameters.
+type RenameGroupRequest struct {
+	// The base URL for the Pushover Groups API. The group
+	// key and action are appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Group key
+	Group string
+
+	// New name of the group
+	Name string
+}
+
+// GroupUpdateResponse is the response from the APIs that change
+// a group. It is read from the body of the Pushover REST API
+// response and translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type GroupUpdateResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// updateGroupContext submits a POST request for an action
+// on a group to the Pushover Groups API
+func (c *Client) updateGroupContext(ctx context.Context, pushoverURL, group, action string, formData url.Values) (*GroupUpdateResponse, error) {
+	pushoverURL = c.endpoint(pushoverURL, groupsURL, groupsPath)
+	formData.Set(keyToken, c.token(formData.Get(keyToken)))
+
+	resp, err := c.transport().PostForm(ctx, pushoverURL+"/"+url.PathEscape(group)+"/"+action+".json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &GroupUpdateResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// groupUserFormData returns the form data for the group
+// member actions
+func groupUserFormData(request GroupUserRequest, withMemo bool) url.Values {
+	formData := url.Values{
+		keyToken: {request.Token},
+		keyUser:  {request.User},
+	}
+
+	if len(request.Device) > 0 {
+		formData.Set(keyDevice, request.Device)
+	}
+
+	if withMemo && len(request.Memo) > 0 {
+		formData.Set(keyMemo, request.Memo)
+	}
+
+	return formData
+}
+
+// AddGroupUserContext will submit a POST request to the Pushover
+// Groups API. This function will add a user to a group.
+//
+//	  resp, err := pushover.AddGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func AddGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return defaultClient.AddGroupUserContext(ctx, request)
+}
+
+// AddGroupUserContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will add a user to a group.
+//
+//	  resp, err := client.AddGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Group: group,
+//		     User:  user,
+//	  })
+func (c *Client) AddGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return c.updateGroupContext(ctx, request.PushoverURL, request.Group, "add_user", groupUserFormData(request, true))
+}
+
+// AddGroupUser will submit a POST request to the Pushover
+// Groups API. This function will add a user to a group.
+//
+//	  resp, err := pushover.AddGroupUser(pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func AddGroupUser(request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return AddGroupUserContext(context.Background(), request)
+}
+
+// RemoveGroupUserContext will submit a POST request to the Pushover
+// Groups API. This function will remove a user from a group.
+//
+//	  resp, err := pushover.RemoveGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func RemoveGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return defaultClient.RemoveGroupUserContext(ctx, request)
+}
+
+// RemoveGroupUserContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will remove a user from a group.
+//
+//	  resp, err := client.RemoveGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Group: group,
+//		     User:  user,
+//	  })
+func (c *Client) RemoveGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return c.updateGroupContext(ctx, request.PushoverURL, request.Group, "delete_user", groupUserFormData(request, false))
+}
+
+// RemoveGroupUser will submit a POST request to the Pushover
+// Groups API. This function will remove a user from a group.
+//
+//	  resp, err := pushover.RemoveGroupUser(pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func RemoveGroupUser(request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return RemoveGroupUserContext(context.Background(), request)
+}
+
+// DisableGroupUserContext will submit a POST request to the Pushover
+// Groups API. This function will temporarily stop a user from
+// receiving messages sent to a group.
+//
+//	  resp, err := pushover.DisableGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func DisableGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return defaultClient.DisableGroupUserContext(ctx, request)
+}
+
+// DisableGroupUserContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will temporarily stop a user from receiving
+// messages sent to a group.
+//
+//	  resp, err := client.DisableGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Group: group,
+//		     User:  user,
+//	  })
+func (c *Client) DisableGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return c.updateGroupContext(ctx, request.PushoverURL, request.Group, "disable_user", groupUserFormData(request, false))
+}
+
+// DisableGroupUser will submit a POST request to the Pushover
+// Groups API. This function will temporarily stop a user from
+// receiving messages sent to a group.
+//
+//	  resp, err := pushover.DisableGroupUser(pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func DisableGroupUser(request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return DisableGroupUserContext(context.Background(), request)
+}
+
+// EnableGroupUserContext will submit a POST request to the Pushover
+// Groups API. This function will re-enable a user previously
+// disabled with DisableGroupUser.
+//
+//	  resp, err := pushover.EnableGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func EnableGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return defaultClient.EnableGroupUserContext(ctx, request)
+}
+
+// EnableGroupUserContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will re-enable a user previously disabled with
+// DisableGroupUser.
+//
+//	  resp, err := client.EnableGroupUserContext(context.Background(),
+//	    pushover.GroupUserRequest{
+//		     Group: group,
+//		     User:  user,
+//	  })
+func (c *Client) EnableGroupUserContext(ctx context.Context, request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return c.updateGroupContext(ctx, request.PushoverURL, request.Group, "enable_user", groupUserFormData(request, false))
+}
+
+// EnableGroupUser will submit a POST request to the Pushover
+// Groups API. This function will re-enable a user previously
+// disabled with DisableGroupUser.
+//
+//	  resp, err := pushover.EnableGroupUser(pushover.GroupUserRequest{
+//		     Token: token,
+//		     Group: group,
+//		     User:  user,
+//	  })
+func EnableGroupUser(request GroupUserRequest) (*GroupUpdateResponse, error) {
+	return EnableGroupUserContext(context.Background(), request)
+}
+
+// RenameGroupContext will submit a POST request to the Pushover
+// Groups API. This function will change the name of a group.
+//
+//	  resp, err := pushover.RenameGroupContext(context.Background(),
+//	    pushover.RenameGroupRequest{
+//		     Token: token,
+//		     Group: group,
+//		     Name:  name,
+//	  })
+func RenameGroupContext(ctx context.Context, request RenameGroupRequest) (*GroupUpdateResponse, error) {
+	return defaultClient.RenameGroupContext(ctx, request)
+}
+
+// RenameGroupContext will submit a POST request to the
+// Pushover Groups API with the client's settings. This
+// function will change the name of a group.
+//
+//	  resp, err := client.RenameGroupContext(context.Background(),
+//	    pushover.RenameGroupRequest{
+//		     Group: group,
+//		     Name:  name,
+//	  })
+func (c *Client) RenameGroupContext(ctx context.Context, request RenameGroupRequest) (*GroupUpdateResponse, error) {
+	formData := url.Values{
+		keyToken: {request.Token},
+		keyName:  {request.Name},
+	}
+
+	return c.updateGroupContext(ctx, request.PushoverURL, request.Group, "rename", formData)
+}
+
+// RenameGroup will submit a POST request to the Pushover
+// Groups API. This function will change the name of a group.
+//
+//	  resp, err := pushover.RenameGroup(pushover.RenameGroupRequest{
+//		     Token: token,
+//		     Group: group,
+//		     Name:  name,
+//	  })
+func RenameGroup(request RenameGroupRequest) (*GroupUpdateResponse, error) {
+	return RenameGroupContext(context.Background(), request)
+}
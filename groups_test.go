@@ -0,0 +1,350 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Create Group
+{"group":"gznej3rKEVAvPUxu9vvNnqpmZpokzF","status":1,"request":"e3a0b4f1-6c5e-4b1e-9c64-3b3e0bb64d52"}
+
+List Groups
+{"groups":[{"group":"gznej3rKEVAvPUxu9vvNnqpmZpokzF","name":"Testing"}],"status":1,"request":"b8a2a7e6-0f2f-4c8c-8d6b-4ed0d1f9f3a3"}
+
+Group Info
+{"name":"Testing","users":[{"user":"uQiRzpo4DXghDmr9QzzfQu27cmVRsG","device":null,"memo":"","disabled":false}],"status":1,"request":"1c2d6d1b-6ef3-4e0e-8c43-0a5d3b1f8e9b"}
+
+Group Invalid
+{"group":"not found","errors":["group not found or you are not authorized to edit it"],"status":0,"request":"7d5e1f3a-2b4c-4a6d-9e8f-0a1b2c3d4e5f"}
+*/
+
+func groupsServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	token := r.Form["token"]
+	if len(token) == 0 || len(token[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token[0] == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"groups":[],"status":1,"request":"%s"`, id)
+		return
+	}
+
+	if token[0] == "failbody" {
+		w.Header().Set("Content-Length", "1")
+		return
+	}
+
+	path := strings.TrimPrefix(strings.TrimSuffix(r.URL.Path, ".json"), "/groups")
+	w.Header().Set("Content-Type", "application/json")
+
+	// Create and list groups
+	if path == "" {
+		if r.Method == http.MethodPost {
+			fmt.Fprintf(w, `{"group":"gznej3rKEVAvPUxu9vvNnqpmZpokzF","status":1,"request":"%s"}`, id)
+		} else {
+			fmt.Fprintf(w, `{"groups":[{"group":"gznej3rKEVAvPUxu9vvNnqpmZpokzF","name":"Testing"},{"group":"g2","name":"Other"}],"status":1,"request":"%s"}`, id)
+		}
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if parts[0] != "gznej3rKEVAvPUxu9vvNnqpmZpokzF" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"group":"not found","errors":["group not found or you are not authorized to edit it"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Group information
+	if len(parts) == 1 {
+		fmt.Fprintf(w, `{"name":"Testing","users":[{"user":"u1","device":null,"memo":"","disabled":false},{"user":"u2","device":"pixel2xl","memo":"on call","disabled":true}],"status":1,"request":"%s"}`, id)
+		return
+	}
+
+	// Group updates
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch parts[1] {
+	case "add_user", "delete_user", "disable_user", "enable_user":
+		user := r.Form["user"]
+		if len(user) == 0 || len(user[0]) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"user":"invalid","errors":["user key is invalid"],"status":0,"request":"%s"}`, id)
+			return
+		}
+	case "rename":
+		name := r.Form["name"]
+		if len(name) == 0 || len(name[0]) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"name":"cannot be blank","errors":["name cannot be blank"],"status":0,"request":"%s"}`, id)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverCreateGroup(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer apiServer.Close()
+
+	var request CreateGroupRequest
+
+	// Default Pushover URL
+	groupsURL = apiServer.URL + "/groups"
+	r, e := CreateGroupContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL + "/groups"
+	request.Token = "testtoken"
+	request.Name = "Testing"
+	r, e = CreateGroup(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.Group != "gznej3rKEVAvPUxu9vvNnqpmZpokzF" || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = CreateGroupContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = CreateGroup(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = CreateGroup(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverListGroups(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer apiServer.Close()
+
+	var request ListGroupsRequest
+
+	// Default Pushover URL
+	groupsURL = apiServer.URL + "/groups"
+	r, e := ListGroupsContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		len(r.Groups) != 0 || r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL + "/groups"
+	request.Token = "testtoken"
+	r, e = ListGroups(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Groups) != 2 || r.Groups[0].Key != "gznej3rKEVAvPUxu9vvNnqpmZpokzF" || r.Groups[0].Name != "Testing" ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = ListGroupsContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid body
+	request.Token = "failbody"
+	_, e = ListGroups(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = ListGroups(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverGroupInfo(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer apiServer.Close()
+
+	var request GroupInfoRequest
+
+	// Default Pushover URL
+	groupsURL = apiServer.URL + "/groups"
+	request.Token = "testtoken"
+	r, e := GroupInfoContext(context.TODO(), request)
+	if !errors.As(e, new(*APIError)) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "group not found or you are not authorized to edit it" || r.ErrorParameters["group"] != "not found" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL + "/groups"
+	request.Group = "gznej3rKEVAvPUxu9vvNnqpmZpokzF"
+	r, e = GroupInfo(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.Group.Key != request.Group || r.Group.Name != "Testing" || len(r.Group.Users) != 2 ||
+		r.Group.Users[0] != (GroupMember{User: "u1"}) ||
+		r.Group.Users[1] != (GroupMember{User: "u2", Device: "pixel2xl", Memo: "on call", Disabled: true}) ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = GroupInfoContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = GroupInfo(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = GroupInfo(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverGroupUser(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer apiServer.Close()
+
+	actions := []struct {
+		name string
+		f    func(GroupUserRequest) (*GroupUpdateResponse, error)
+		fctx func(context.Context, GroupUserRequest) (*GroupUpdateResponse, error)
+	}{
+		{name: "add", f: AddGroupUser, fctx: AddGroupUserContext},
+		{name: "remove", f: RemoveGroupUser, fctx: RemoveGroupUserContext},
+		{name: "disable", f: DisableGroupUser, fctx: DisableGroupUserContext},
+		{name: "enable", f: EnableGroupUser, fctx: EnableGroupUserContext},
+	}
+
+	for _, action := range actions {
+		var request GroupUserRequest
+
+		// Default Pushover URL
+		groupsURL = apiServer.URL + "/groups"
+		request.Token = "testtoken"
+		request.Group = "gznej3rKEVAvPUxu9vvNnqpmZpokzF"
+		r, e := action.fctx(context.TODO(), request)
+		if !errors.Is(e, ErrInvalidUser) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+			r.Errors[0] != "user key is invalid" || r.ErrorParameters["user"] != "invalid" {
+			t.Error("Default Pushover URL", action.name)
+		}
+
+		// Valid submission
+		request.PushoverURL = apiServer.URL + "/groups"
+		request.User = "u1"
+		request.Device = "pixel2xl"
+		request.Memo = "on call"
+		r, e = action.f(request)
+		if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+			len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+			t.Error("Valid submit data", action.name)
+		}
+
+		// Invalid group
+		request.Group = "invalid"
+		r, _ = action.f(request)
+		if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.ErrorParameters["group"] != "not found" {
+			t.Error("Invalid group", action.name)
+		}
+
+		// Context cancellation
+		ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+		_, e = action.fctx(ctx, request)
+		if e != context.DeadlineExceeded {
+			t.Error("Context deadline exceeded", action.name)
+		}
+		cancel()
+
+		// Invalid json response
+		request.Token = "failjson"
+		_, e = action.f(request)
+		if _, ok := e.(*ErrInvalidResponse); !ok {
+			t.Error("Invalid response JSON", action.name)
+		}
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e := AddGroupUser(GroupUserRequest{PushoverURL: apiServer.URL + "/groups"})
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverRenameGroup(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer apiServer.Close()
+
+	var request RenameGroupRequest
+
+	// Default Pushover URL
+	groupsURL = apiServer.URL + "/groups"
+	request.Token = "testtoken"
+	request.Group = "gznej3rKEVAvPUxu9vvNnqpmZpokzF"
+	r, e := RenameGroupContext(context.TODO(), request)
+	if !errors.As(e, new(*APIError)) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "name cannot be blank" || r.ErrorParameters["name"] != "cannot be blank" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL + "/groups"
+	request.Name = "Renamed"
+	r, e = RenameGroup(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = RenameGroup(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
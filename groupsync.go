@@ -0,0 +1,303 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// GroupSyncAction is the change made to a group member by
+// ApplyGroupSync
+type GroupSyncAction string
+
+const (
+	// GroupSyncAdd adds the member to the group
+	GroupSyncAdd GroupSyncAction = "add"
+
+	// GroupSyncRemove removes the member from the group
+	GroupSyncRemove GroupSyncAction = "remove"
+
+	// GroupSyncDisable disables the member in the group
+	GroupSyncDisable GroupSyncAction = "disable"
+
+	// GroupSyncEnable enables the member in the group
+	GroupSyncEnable GroupSyncAction = "enable"
+
+	// GroupSyncMemo changes the memo of the member. The
+	// Pushover Groups API cannot change a memo, so the member
+	// is removed and added again with the new memo.
+	GroupSyncMemo GroupSyncAction = "memo"
+)
+
+// GroupSyncRequest is the desired membership of a group for
+// PlanGroupSync and ApplyGroupSync
+type GroupSyncRequest struct {
+	// The base URL for the Pushover Groups API.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// The URL for the Pushover Validate API used to check
+	// members before they are added.
+	//
+	// If empty, members are checked with the client's BaseURL
+	// or the Pushover Validate API. When PushoverURL is set
+	// without a client BaseURL, members are not checked unless
+	// ValidateURL is also set, so the token and user keys are
+	// not sent to the Pushover Validate API.
+	ValidateURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Group key
+	Group string
+
+	// The desired members of the group
+	//
+	// Members are identified by their user key and device.
+	Members []GroupMember
+}
+
+// GroupSyncChange is a single change in a GroupSyncPlan
+type GroupSyncChange struct {
+	// The change to make
+	Action GroupSyncAction
+
+	// The member as it should be after the change. For
+	// GroupSyncRemove, the member as it currently is.
+	Member GroupMember
+}
+
+// GroupSyncInvalid is a desired member rejected by the
+// Pushover Validate API. Invalid members are not added.
+type GroupSyncInvalid struct {
+	// The rejected member
+	Member GroupMember
+
+	// List of errors returned by the Pushover Validate API
+	Errors []string
+}
+
+// GroupSyncPlan is the list of changes to make a group match
+// its desired membership
+type GroupSyncPlan struct {
+	// Response for the current group
+	//
+	// When its APIStatus is not 1, the group could not be
+	// read, the plan has no changes and is returned with an
+	// APIError.
+	Current *GroupInfoResponse
+
+	// Changes to apply, in the order they will be applied
+	Changes []GroupSyncChange
+
+	// Desired members not added because they are invalid
+	Invalid []GroupSyncInvalid
+}
+
+// GroupSyncResult is the outcome of applying a GroupSyncChange
+type GroupSyncResult struct {
+	// The applied change
+	Change GroupSyncChange
+
+	// Responses for the requests made for the change
+	Responses []*GroupUpdateResponse
+}
+
+func groupMemberKey(m GroupMember) string {
+	return m.User + "\x00" + m.Device
+}
+
+// PlanGroupSync will retrieve the current members of a group
+// from the Pushover Groups API and compare them with the
+// desired members. Members to be added are checked with the
+// Pushover Validate API first, as described for ValidateURL in
+// GroupSyncRequest. Nothing is changed; the returned plan is
+// applied with ApplyGroupSync.
+//
+//	  plan, err := pushover.PlanGroupSync(context.Background(),
+//	    pushover.GroupSyncRequest{
+//		     Token:   token,
+//		     Group:   group,
+//		     Members: members,
+//	  })
+func PlanGroupSync(ctx context.Context, request GroupSyncRequest) (*GroupSyncPlan, error) {
+	return defaultClient.PlanGroupSync(ctx, request)
+}
+
+// PlanGroupSync will compare the current members of a group
+// with the desired members using the client's settings.
+// Nothing is changed; the returned plan is applied with
+// ApplyGroupSync.
+//
+//	  plan, err := client.PlanGroupSync(context.Background(),
+//	    pushover.GroupSyncRequest{
+//		     Group:   group,
+//		     Members: members,
+//	  })
+func (c *Client) PlanGroupSync(ctx context.Context, request GroupSyncRequest) (*GroupSyncPlan, error) {
+	current, err := c.GroupInfoContext(ctx, GroupInfoRequest{
+		PushoverURL: request.PushoverURL,
+		Token:       request.Token,
+		Group:       request.Group,
+	})
+	var apiErr *APIError
+	if err != nil && !errors.As(err, &apiErr) {
+		return nil, err
+	}
+
+	plan := &GroupSyncPlan{
+		Current: current,
+		Changes: []GroupSyncChange{},
+		Invalid: []GroupSyncInvalid{},
+	}
+
+	if err != nil {
+		return plan, err
+	}
+
+	currentMembers := make(map[string]GroupMember)
+	for _, m := range current.Group.Users {
+		currentMembers[groupMemberKey(m)] = m
+	}
+
+	// Validate members at a stand-in for the groups URL only
+	// if the validate URL is known
+	validate := len(request.ValidateURL) > 0 || len(request.PushoverURL) == 0 || len(c.BaseURL) > 0
+
+	desiredMembers := make(map[string]bool)
+	for _, m := range request.Members {
+		key := groupMemberKey(m)
+		if desiredMembers[key] {
+			continue
+		}
+		desiredMembers[key] = true
+
+		existing, ok := currentMembers[key]
+		if !ok {
+			if validate {
+				v, err := c.ValidateContext(ctx, ValidateRequest{
+					PushoverURL: request.ValidateURL,
+					Token:       request.Token,
+					User:        m.User,
+					Device:      m.Device,
+				})
+				if err != nil && !errors.As(err, &apiErr) {
+					return nil, err
+				}
+
+				if err != nil {
+					plan.Invalid = append(plan.Invalid, GroupSyncInvalid{Member: m, Errors: v.Errors})
+					continue
+				}
+			}
+
+			plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncAdd, Member: m})
+			if m.Disabled {
+				plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncDisable, Member: m})
+			}
+
+			continue
+		}
+
+		switch {
+		case existing.Memo != m.Memo:
+			// Re-adding the member enables it
+			plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncMemo, Member: m})
+			if m.Disabled {
+				plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncDisable, Member: m})
+			}
+		case existing.Disabled && !m.Disabled:
+			plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncEnable, Member: m})
+		case !existing.Disabled && m.Disabled:
+			plan.Changes = append(plan.Changes, GroupSyncChange{Action: GroupSyncDisable, Member: m})
+		}
+	}
+
+	removes := []GroupSyncChange{}
+	for key, m := range currentMembers {
+		if !desiredMembers[key] {
+			removes = append(removes, GroupSyncChange{Action: GroupSyncRemove, Member: m})
+		}
+	}
+	sort.Slice(removes, func(i, j int) bool {
+		return groupMemberKey(removes[i].Member) < groupMemberKey(removes[j].Member)
+	})
+
+	// Remove members first so a group is never larger than
+	// its desired size
+	plan.Changes = append(removes, plan.Changes...)
+
+	return plan, nil
+}
+
+// ApplyGroupSync will submit the changes in a plan created by
+// PlanGroupSync to the Pushover Groups API. A result is returned
+// for each change applied. Changes rejected by the Pushover API
+// do not stop the remaining changes; check the APIStatus of the
+// responses in each result. Any other error stops the remaining
+// changes and is returned with the results so far.
+//
+//	results, err := pushover.ApplyGroupSync(context.Background(),
+//	  request, plan)
+func ApplyGroupSync(ctx context.Context, request GroupSyncRequest, plan *GroupSyncPlan) ([]GroupSyncResult, error) {
+	return defaultClient.ApplyGroupSync(ctx, request, plan)
+}
+
+// ApplyGroupSync will submit the changes in a plan created by
+// PlanGroupSync to the Pushover Groups API with the client's
+// settings.
+//
+//	results, err := client.ApplyGroupSync(context.Background(),
+//	  request, plan)
+func (c *Client) ApplyGroupSync(ctx context.Context, request GroupSyncRequest, plan *GroupSyncPlan) ([]GroupSyncResult, error) {
+	results := []GroupSyncResult{}
+
+	var apiErr *APIError
+
+	for _, change := range plan.Changes {
+		userRequest := GroupUserRequest{
+			PushoverURL: request.PushoverURL,
+			Token:       request.Token,
+			Group:       request.Group,
+			User:        change.Member.User,
+			Device:      change.Member.Device,
+			Memo:        change.Member.Memo,
+		}
+
+		var updates []func(context.Context, GroupUserRequest) (*GroupUpdateResponse, error)
+
+		switch change.Action {
+		case GroupSyncAdd:
+			updates = append(updates, c.AddGroupUserContext)
+		case GroupSyncRemove:
+			updates = append(updates, c.RemoveGroupUserContext)
+		case GroupSyncDisable:
+			updates = append(updates, c.DisableGroupUserContext)
+		case GroupSyncEnable:
+			updates = append(updates, c.EnableGroupUserContext)
+		case GroupSyncMemo:
+			updates = append(updates, c.RemoveGroupUserContext, c.AddGroupUserContext)
+		}
+
+		result := GroupSyncResult{Change: change, Responses: []*GroupUpdateResponse{}}
+		for _, update := range updates {
+			r, err := update(ctx, userRequest)
+			if err != nil && !errors.As(err, &apiErr) {
+				return results, err
+			}
+
+			result.Responses = append(result.Responses, r)
+			if err != nil {
+				break
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,196 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPlanGroupSync(t *testing.T) {
+	groupsServer := httptest.NewServer(http.HandlerFunc(groupsServerHandler))
+	defer groupsServer.Close()
+	validateServer := httptest.NewServer(http.HandlerFunc(validateServerHandler))
+	defer validateServer.Close()
+
+	// Current members are u1 and u2 on pixel2xl, disabled, with memo "on call"
+	request := GroupSyncRequest{
+		PushoverURL: groupsServer.URL + "/groups",
+		ValidateURL: validateServer.URL,
+		Token:       "testtoken",
+		Group:       "gznej3rKEVAvPUxu9vvNnqpmZpokzF",
+		Members: []GroupMember{
+			{User: "u1", Memo: "Alice"},
+			{User: "u2", Device: "pixel2xl", Memo: "on call"},
+			{User: "u3", Disabled: true},
+			{User: "u3", Disabled: true},
+			{User: ""},
+		},
+	}
+
+	// Memo, enable, add, disable and invalid
+	plan, e := PlanGroupSync(context.TODO(), request)
+	expected := []GroupSyncChange{
+		{Action: GroupSyncMemo, Member: GroupMember{User: "u1", Memo: "Alice"}},
+		{Action: GroupSyncEnable, Member: GroupMember{User: "u2", Device: "pixel2xl", Memo: "on call"}},
+		{Action: GroupSyncAdd, Member: GroupMember{User: "u3", Disabled: true}},
+		{Action: GroupSyncDisable, Member: GroupMember{User: "u3", Disabled: true}},
+	}
+	if e != nil || plan.Current.APIStatus != 1 || !reflect.DeepEqual(plan.Changes, expected) ||
+		len(plan.Invalid) != 1 || plan.Invalid[0].Errors[0] != "user key is invalid" {
+		t.Error("Plan changes", plan.Changes)
+	}
+
+	// Remove, disable and memo with disable
+	request.Members = []GroupMember{
+		{User: "u1", Memo: "Alice", Disabled: true},
+	}
+	plan, e = PlanGroupSync(context.TODO(), request)
+	expected = []GroupSyncChange{
+		{Action: GroupSyncRemove, Member: GroupMember{User: "u2", Device: "pixel2xl", Memo: "on call", Disabled: true}},
+		{Action: GroupSyncMemo, Member: GroupMember{User: "u1", Memo: "Alice", Disabled: true}},
+		{Action: GroupSyncDisable, Member: GroupMember{User: "u1", Memo: "Alice", Disabled: true}},
+	}
+	if e != nil || !reflect.DeepEqual(plan.Changes, expected) || len(plan.Invalid) != 0 {
+		t.Error("Plan removes", plan.Changes)
+	}
+
+	// Disable existing member
+	request.Members = []GroupMember{
+		{User: "u1", Disabled: true},
+		{User: "u2", Device: "pixel2xl", Memo: "on call", Disabled: true},
+	}
+	plan, e = PlanGroupSync(context.TODO(), request)
+	expected = []GroupSyncChange{
+		{Action: GroupSyncDisable, Member: GroupMember{User: "u1", Disabled: true}},
+	}
+	if e != nil || !reflect.DeepEqual(plan.Changes, expected) {
+		t.Error("Plan disable", plan.Changes)
+	}
+
+	// Invalid group
+	request.Group = "invalid"
+	plan, e = PlanGroupSync(context.TODO(), request)
+	if !errors.As(e, new(*APIError)) || plan.Current.APIStatus != 0 || len(plan.Changes) != 0 {
+		t.Error("Invalid group")
+	}
+	request.Group = "gznej3rKEVAvPUxu9vvNnqpmZpokzF"
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = PlanGroupSync(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Validate API failure
+	request.Members = []GroupMember{{User: "u3"}}
+	validateServer.Close()
+	_, e = PlanGroupSync(context.TODO(), request)
+	if e == nil {
+		t.Error("No validate API server")
+	}
+
+	// Stand-in for the Pushover Validate API, which must not
+	// receive the token of a group at another URL
+	var defaultRequests int32
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&defaultRequests, 1)
+		validateServerHandler(w, r)
+	}))
+	defer defaultServer.Close()
+
+	savedValidateURL := validateURL
+	validateURL = defaultServer.URL
+	defer func() { validateURL = savedValidateURL }()
+
+	// Not validated without a validate URL for the groups URL
+	request.ValidateURL = ""
+	request.Members = []GroupMember{{User: ""}}
+	plan, e = PlanGroupSync(context.TODO(), request)
+	expected = []GroupSyncChange{
+		{Action: GroupSyncRemove, Member: GroupMember{User: "u1"}},
+		{Action: GroupSyncRemove, Member: GroupMember{User: "u2", Device: "pixel2xl", Memo: "on call", Disabled: true}},
+		{Action: GroupSyncAdd, Member: GroupMember{User: ""}},
+	}
+	if e != nil || !reflect.DeepEqual(plan.Changes, expected) || len(plan.Invalid) != 0 ||
+		atomic.LoadInt32(&defaultRequests) != 0 {
+		t.Error("Groups URL without validate URL", plan.Changes)
+	}
+}
+
+func TestApplyGroupSync(t *testing.T) {
+	var mu sync.Mutex
+	paths := []string{}
+	groupsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		mu.Lock()
+		paths = append(paths, r.URL.Path+" "+r.Form.Get("user"))
+		mu.Unlock()
+		groupsServerHandler(w, r)
+	}))
+	defer groupsServer.Close()
+
+	request := GroupSyncRequest{
+		PushoverURL: groupsServer.URL + "/groups",
+		Token:       "testtoken",
+		Group:       "gznej3rKEVAvPUxu9vvNnqpmZpokzF",
+	}
+
+	plan := &GroupSyncPlan{
+		Changes: []GroupSyncChange{
+			{Action: GroupSyncRemove, Member: GroupMember{User: "u2"}},
+			{Action: GroupSyncMemo, Member: GroupMember{User: "u1", Memo: "Alice"}},
+			{Action: GroupSyncAdd, Member: GroupMember{User: "u3"}},
+			{Action: GroupSyncDisable, Member: GroupMember{User: "u3"}},
+			{Action: GroupSyncEnable, Member: GroupMember{User: "u4"}},
+		},
+	}
+
+	// All changes applied
+	results, e := ApplyGroupSync(context.TODO(), request, plan)
+	if e != nil || len(results) != 5 || len(results[1].Responses) != 2 {
+		t.Error("Apply changes")
+	}
+	group := "/groups/gznej3rKEVAvPUxu9vvNnqpmZpokzF/"
+	expected := []string{
+		group + "delete_user.json u2",
+		group + "delete_user.json u1",
+		group + "add_user.json u1",
+		group + "add_user.json u3",
+		group + "disable_user.json u3",
+		group + "enable_user.json u4",
+	}
+	mu.Lock()
+	if !reflect.DeepEqual(paths, expected) {
+		t.Error("Apply paths", paths)
+	}
+	mu.Unlock()
+	for _, result := range results {
+		for _, r := range result.Responses {
+			if r.APIStatus != 1 {
+				t.Error("Apply change", result.Change.Action)
+			}
+		}
+	}
+
+	// Rejected change stops the memo change
+	request.Group = "invalid"
+	results, e = ApplyGroupSync(context.TODO(), request, plan)
+	if e != nil || len(results) != 5 || len(results[1].Responses) != 1 || results[1].Responses[0].APIStatus != 0 {
+		t.Error("Apply rejected changes")
+	}
+
+	// Request failure stops the changes
+	groupsServer.Close()
+	results, e = ApplyGroupSync(context.TODO(), request, plan)
+	if e == nil || len(results) != 0 {
+		t.Error("No API server")
+	}
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	keyErrors  = "errors"
+	keyRequest = "request"
+	keyStatus  = "status"
+)
+
+// Response holds the fields common to every Pushover API
+// response. The remaining, endpoint specific, fields are left
+// in Result for the caller to translate.
+type Response struct {
+	ResponseBody   string
+	HTTPStatus     string
+	HTTPStatusCode int
+	APIStatus      int
+	Request        string
+	Result         map[string]interface{}
+}
+
+// ReadResponse reads the body of a Pushover API response and
+// decodes the status and request ID fields present in every
+// response. The error is returned if the body cannot be read
+// or decoded, for the caller to wrap.
+func ReadResponse(resp *http.Response) (*Response, error) {
+	body := &bytes.Buffer{}
+	_, err := body.ReadFrom(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Response{
+		ResponseBody:   body.String(),
+		HTTPStatus:     resp.Status,
+		HTTPStatusCode: resp.StatusCode,
+	}
+
+	// Decode json response
+	if e := json.NewDecoder(strings.NewReader(r.ResponseBody)).Decode(&r.Result); e != nil {
+		return nil, e
+	}
+
+	var ok bool
+
+	// Populate request status
+	if r.APIStatus, ok = MapKeyToInt(keyStatus, r.Result); !ok {
+		return nil, errors.New("missing status")
+	}
+	delete(r.Result, keyStatus)
+
+	// Populate request ID
+	if r.Request, ok = r.Result[keyRequest].(string); !ok {
+		return nil, errors.New("missing request ID")
+	}
+	delete(r.Result, keyRequest)
+
+	return r, nil
+}
+
+// ErrorFields returns the list of errors and the map of
+// parameters with corresponding errors. It must be called
+// after all other fields are removed from the result.
+//
+// Some Open Client APIs return the errors as a map of
+// parameters to lists of errors. These are added to both the
+// list of errors and the map of parameters.
+func (r *Response) ErrorFields() ([]string, map[string]string) {
+	errors := []string{}
+	parameters := make(map[string]string)
+
+	switch values := r.Result[keyErrors].(type) {
+	case []interface{}:
+		for _, v := range values {
+			errors = append(errors, fmt.Sprintf("%v", v))
+		}
+	case map[string]interface{}:
+		for k, v := range values {
+			var messages []string
+			if list, ok := v.([]interface{}); ok {
+				for _, m := range list {
+					messages = append(messages, fmt.Sprintf("%v", m))
+				}
+			} else {
+				messages = append(messages, fmt.Sprintf("%v", v))
+			}
+
+			for _, m := range messages {
+				errors = append(errors, k+" "+m)
+			}
+			parameters[k] = strings.Join(messages, ", ")
+		}
+		sort.Strings(errors)
+	}
+	delete(r.Result, keyErrors)
+
+	for k, v := range r.Result {
+		parameters[k] = fmt.Sprintf("%v", v)
+	}
+
+	return errors, parameters
+}
+
+// MapKeyToInt converts a JSON number to an int. The second
+// result is false if the key is missing or not a number.
+func MapKeyToInt(key string, m map[string]interface{}) (int, bool) {
+	var value float64
+	var result int
+	var ok bool
+
+	if value, ok = m[key].(float64); ok {
+		result = int(value)
+	}
+
+	return result, ok
+}
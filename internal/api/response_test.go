@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type failReader struct{}
+
+func (fr failReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func response(body string) *http.Response {
+	return &http.Response{
+		Status:     "400 Bad Request",
+		StatusCode: http.StatusBadRequest,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestReadResponse(t *testing.T) {
+	// Common fields are decoded and removed from the result
+	r, e := ReadResponse(response(`{"status":0,"request":"id","receipt":"receipt"}`))
+	if e != nil || r.HTTPStatus != "400 Bad Request" || r.HTTPStatusCode != http.StatusBadRequest ||
+		r.APIStatus != 0 || r.Request != "id" || len(r.Result) != 1 || r.Result["receipt"] != "receipt" {
+		t.Errorf("Response %+v", r)
+	}
+
+	// Body cannot be read
+	resp := response("")
+	resp.Body = io.NopCloser(failReader{})
+	if _, e = ReadResponse(resp); e == nil || e.Error() != "read failed" {
+		t.Error("Read failure")
+	}
+
+	// Body is not JSON
+	var syntaxErr *json.SyntaxError
+	if _, e = ReadResponse(response("<html>")); !errors.As(e, &syntaxErr) {
+		t.Error("Invalid JSON")
+	}
+
+	// Missing status
+	if _, e = ReadResponse(response(`{"request":"id"}`)); e == nil || e.Error() != "missing status" {
+		t.Error("Missing status")
+	}
+
+	// Missing request ID
+	if _, e = ReadResponse(response(`{"status":1}`)); e == nil || e.Error() != "missing request ID" {
+		t.Error("Missing request ID")
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	// List of errors
+	r, _ := ReadResponse(response(`{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"id"}`))
+	errs, parameters := r.ErrorFields()
+	if !reflect.DeepEqual(errs, []string{"user identifier is invalid"}) ||
+		!reflect.DeepEqual(parameters, map[string]string{"user": "invalid"}) {
+		t.Errorf("List of errors %v %v", errs, parameters)
+	}
+
+	// Map of parameters to errors
+	r, _ = ReadResponse(response(`{"errors":{"name":["is too long","is invalid"],"os":"is invalid"},"status":0,"request":"id"}`))
+	errs, parameters = r.ErrorFields()
+	if !reflect.DeepEqual(errs, []string{"name is invalid", "name is too long", "os is invalid"}) ||
+		!reflect.DeepEqual(parameters, map[string]string{"name": "is too long, is invalid", "os": "is invalid"}) {
+		t.Errorf("Map of errors %v %v", errs, parameters)
+	}
+
+	// No errors
+	r, _ = ReadResponse(response(`{"status":1,"request":"id"}`))
+	errs, parameters = r.ErrorFields()
+	if errs == nil || len(errs) > 0 || len(parameters) > 0 {
+		t.Error("No errors")
+	}
+}
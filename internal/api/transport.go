@@ -0,0 +1,105 @@
+// Package api implements the HTTP requests and response
+// decoding shared by the pushover and openclient packages
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Transport submits requests to the Pushover API
+type Transport struct {
+	// HTTP client used to submit requests
+	//
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// User-Agent header sent with each request
+	//
+	// If empty, the Go HTTP client default is sent.
+	UserAgent string
+
+	// Time limit for each request, including reading the
+	// response
+	//
+	// If zero, requests are only limited by their context.
+	Timeout time.Duration
+}
+
+func (t *Transport) httpClient() *http.Client {
+	if t.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return t.HTTPClient
+}
+
+// cancelBody cancels the context of a request when its
+// response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// Do submits a request to the Pushover API with the
+// transport's HTTP client, user agent and timeout
+func (t *Transport) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if t.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+	}
+
+	if len(t.UserAgent) > 0 {
+		req.Header.Set("User-Agent", t.UserAgent)
+	}
+
+	resp, err := t.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		defer cancel()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		return nil, err
+	}
+
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// GetURL submits a GET request to the Pushover API
+func (t *Transport) GetURL(ctx context.Context, apiURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Do(ctx, req)
+}
+
+// PostForm submits a POST request with form data to the
+// Pushover API
+func (t *Transport) PostForm(ctx context.Context, apiURL string, formData url.Values) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, apiURL, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return t.Do(ctx, req)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func transportServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Form.Get("slow") == "1" {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	fmt.Fprintf(w, "%s %s %s", r.Method, r.UserAgent(), r.Form.Get("value"))
+}
+
+func readBody(resp *http.Response) string {
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return string(body)
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(transportServerHandler))
+	defer server.Close()
+
+	if (&Transport{}).httpClient() != http.DefaultClient {
+		t.Error("Default HTTP client")
+	}
+
+	transport := &Transport{HTTPClient: &http.Client{}, UserAgent: "pushover-test/1.0", Timeout: time.Second}
+	ctx := context.TODO()
+
+	// GET with the user agent
+	resp, e := transport.GetURL(ctx, server.URL+"?value=get")
+	if e != nil || readBody(resp) != "GET pushover-test/1.0 get" {
+		t.Error("GET")
+	}
+
+	// POST form data
+	resp, e = transport.PostForm(ctx, server.URL, url.Values{"value": {"post"}})
+	if e != nil || readBody(resp) != "POST pushover-test/1.0 post" {
+		t.Error("POST")
+	}
+
+	// Request exceeds the timeout
+	transport.Timeout = 10 * time.Millisecond
+	if _, e = transport.GetURL(ctx, server.URL+"?slow=1"); e != context.DeadlineExceeded {
+		t.Error("Timeout exceeded")
+	}
+
+	// Context cancellation
+	transport.Timeout = 0
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, e = transport.GetURL(cancelled, server.URL); e != context.Canceled {
+		t.Error("Context cancelled")
+	}
+
+	// Connection failure
+	if _, e = transport.GetURL(ctx, "http://127.0.0.1:0"); e == nil {
+		t.Error("Connection failure")
+	}
+
+	// Invalid URL
+	if _, e = transport.GetURL(ctx, "://"); e == nil {
+		t.Error("Invalid GET URL")
+	}
+	if _, e = transport.PostForm(ctx, "://", nil); e == nil {
+		t.Error("Invalid POST URL")
+	}
+}
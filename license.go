@@ -0,0 +1,292 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+// Platforms a license can be assigned for. Leave the OS field
+// of AssignLicenseRequest empty to allow the user to use the
+// license on any platform.
+const (
+	LicenseAndroid = "Android"
+	LicenseIOS     = "iOS"
+	LicenseDesktop = "Desktop"
+)
+
+// LicenseCreditsRequest is the data for the GET to the Pushover
+// Licensing API. See the Pushover Licensing API documentation
+// for more information on these parameters.
+type LicenseCreditsRequest struct {
+	// The base URL for the Pushover Licensing API.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+}
+
+// LicenseCreditsResponse is the response from this API. It is
+// read from the body of the Pushover REST API response and
+// translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type LicenseCreditsResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Number of license credits remaining
+	Credits int
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// AssignLicenseRequest is the data for the POST to the Pushover
+// Licensing API. Either User or Email must be set. See the
+// Pushover Licensing API documentation for more information on
+// these parameters.
+type AssignLicenseRequest struct {
+	// The base URL for the Pushover Licensing API. The assign
+	// path is appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// The user's key
+	User string
+
+	// The user's email address. If the user does not have a
+	// Pushover account, one is created.
+	Email string
+
+	// Optional Fields
+
+	// The platform the license is for. One of LicenseAndroid,
+	// LicenseIOS or LicenseDesktop. Leave empty to allow any
+	// platform.
+	OS string
+}
+
+// AssignLicenseResponse is the response from this API. It is
+// read from the body of the Pushover REST API response and
+// translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type AssignLicenseResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// LicenseCreditsContext will submit a GET request to the
+// Pushover Licensing API. This function will retrieve the
+// number of license credits remaining for the application.
+//
+//	  resp, err := pushover.LicenseCreditsContext(context.Background(),
+//	    pushover.LicenseCreditsRequest{
+//		     Token: token,
+//	  })
+func LicenseCreditsContext(ctx context.Context, request LicenseCreditsRequest) (*LicenseCreditsResponse, error) {
+	return defaultClient.LicenseCreditsContext(ctx, request)
+}
+
+// LicenseCreditsContext will submit a GET request to the
+// Pushover Licensing API with the client's settings. This
+// function will retrieve the number of license credits
+// remaining for the application.
+//
+//	resp, err := client.LicenseCreditsContext(context.Background(),
+//	  pushover.LicenseCreditsRequest{})
+func (c *Client) LicenseCreditsContext(ctx context.Context, request LicenseCreditsRequest) (*LicenseCreditsResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, licensesURL, licensesPath)
+	request.Token = c.token(request.Token)
+
+	query := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+".json?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LicenseCreditsResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate credits
+	var ok bool
+	if r.Credits, ok = api.MapKeyToInt(keyCredits, a.Result); ok {
+		delete(a.Result, keyCredits)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// LicenseCredits will submit a GET request to the
+// Pushover Licensing API. This function will retrieve the
+// number of license credits remaining for the application.
+//
+//	  resp, err := pushover.LicenseCredits(pushover.LicenseCreditsRequest{
+//		     Token: token,
+//	  })
+func LicenseCredits(request LicenseCreditsRequest) (*LicenseCreditsResponse, error) {
+	return LicenseCreditsContext(context.Background(), request)
+}
+
+// AssignLicenseContext will submit a POST request to the
+// Pushover Licensing API. This function will assign a license
+// credit to a user by their key or email address.
+//
+//	  resp, err := pushover.AssignLicenseContext(context.Background(),
+//	    pushover.AssignLicenseRequest{
+//		     Token: token,
+//		     Email: email,
+//		     OS:    pushover.LicenseAndroid,
+//	  })
+func AssignLicenseContext(ctx context.Context, request AssignLicenseRequest) (*AssignLicenseResponse, error) {
+	return defaultClient.AssignLicenseContext(ctx, request)
+}
+
+// AssignLicenseContext will submit a POST request to the
+// Pushover Licensing API with the client's settings. This
+// function will assign a license credit to a user by their
+// key or email address.
+//
+//	  resp, err := client.AssignLicenseContext(context.Background(),
+//	    pushover.AssignLicenseRequest{
+//		     Email: email,
+//		     OS:    pushover.LicenseAndroid,
+//	  })
+func (c *Client) AssignLicenseContext(ctx context.Context, request AssignLicenseRequest) (*AssignLicenseResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, licensesURL, licensesPath)
+	request.Token = c.token(request.Token)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: keyToken, value: request.Token},
+		{field: keyUser, value: request.User},
+		{field: keyEmail, value: request.Email},
+		{field: keyOS, value: request.OS},
+	}
+
+	formData := url.Values{}
+	for _, v := range fields {
+		if len(v.value) > 0 {
+			formData.Set(v.field, v.value)
+		}
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL+"/assign.json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &AssignLicenseResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// AssignLicense will submit a POST request to the
+// Pushover Licensing API. This function will assign a license
+// credit to a user by their key or email address.
+//
+//	  resp, err := pushover.AssignLicense(pushover.AssignLicenseRequest{
+//		     Token: token,
+//		     Email: email,
+//		     OS:    pushover.LicenseAndroid,
+//	  })
+func AssignLicense(request AssignLicenseRequest) (*AssignLicenseResponse, error) {
+	return AssignLicenseContext(context.Background(), request)
+}
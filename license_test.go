@@ -0,0 +1,211 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Credits Valid
+{"credits":5,"status":1,"request":"c1a2b3d4-e5f6-4789-8abc-def012345678"}
+
+Assign Valid
+{"status":1,"request":"a9b8c7d6-e5f4-4321-8fed-cba987654321"}
+
+Assign No Credits
+{"errors":["no license credits remaining"],"status":0,"request":"0f1e2d3c-4b5a-4697-8877-665544332211"}
+
+Assign Invalid OS
+{"os":"invalid","errors":["os is invalid"],"status":0,"request":"7a6b5c4d-3e2f-4101-9f8e-7d6c5b4a3928"}
+*/
+
+func licenseServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	token := r.Form.Get("token")
+	if len(token) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"credits":5,"status":1,"request":"%s"`, id)
+		return
+	}
+
+	if !strings.HasSuffix(r.URL.Path, "/licenses/assign.json") {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/licenses.json") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"credits":5,"status":1,"request":"%s"}`, id)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check user or email
+	if len(r.Form.Get("user")) == 0 && len(r.Form.Get("email")) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"user":"invalid","errors":["user or email must be supplied"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check os
+	switch r.Form.Get("os") {
+	case "", "Android", "iOS", "Desktop":
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"os":"invalid","errors":["os is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token == "nocredits" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"errors":["no license credits remaining"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverLicenseCredits(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(licenseServerHandler))
+	defer apiServer.Close()
+
+	var request LicenseCreditsRequest
+
+	// Default Pushover URL
+	licensesURL = apiServer.URL + "/licenses"
+	r, e := LicenseCreditsContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL + "/licenses"
+	request.Token = "testtoken"
+	r, e = LicenseCredits(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.Credits != 5 || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = LicenseCreditsContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = LicenseCredits(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = LicenseCredits(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverAssignLicense(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(licenseServerHandler))
+	defer apiServer.Close()
+
+	var request AssignLicenseRequest
+
+	// Default Pushover URL
+	licensesURL = apiServer.URL + "/licenses"
+	r, e := AssignLicenseContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no user or email
+	request.PushoverURL = apiServer.URL + "/licenses"
+	request.Token = "testtoken"
+	r, _ = AssignLicense(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		r.Errors[0] != "user or email must be supplied" || r.ErrorParameters["user"] != "invalid" {
+		t.Error("Handling of no user or email")
+	}
+
+	// Invalid OS
+	request.User = "testuser"
+	request.OS = "Windows"
+	r, _ = AssignLicense(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.ErrorParameters["os"] != "invalid" {
+		t.Error("Invalid OS")
+	}
+
+	// Assign to user
+	request.OS = LicenseAndroid
+	r, e = AssignLicense(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Assign to user")
+	}
+
+	// Assign to email
+	request.User = ""
+	request.Email = "user@example.com"
+	request.OS = ""
+	r, e = AssignLicense(request)
+	if e != nil || r.APIStatus != 1 {
+		t.Error("Assign to email")
+	}
+
+	// No credits remaining
+	request.Token = "nocredits"
+	r, _ = AssignLicense(request)
+	if r.APIStatus != 0 || r.Errors[0] != "no license credits remaining" || len(r.ErrorParameters) > 0 {
+		t.Error("No credits remaining")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = AssignLicenseContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = AssignLicense(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = AssignLicense(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
@@ -0,0 +1,149 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+// LimitsRequest is the data for the GET to the Pushover
+// Limits API. See the Pushover API documentation for more
+// information on these parameters.
+type LimitsRequest struct {
+	// The URL for the Pushover REST API GET.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+}
+
+// LimitsResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type LimitsResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Number of messages the application may send each
+	// month
+	Limit int
+
+	// Number of messages remaining this month
+	Remaining int
+
+	// Time the remaining count is reset to the limit
+	Reset time.Time
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// LimitsContext will submit a GET request to the Pushover
+// Limits API. This function will retrieve the monthly message
+// limit of an application and how many messages remain.
+//
+//	  resp, err := pushover.LimitsContext(context.Background(),
+//	    pushover.LimitsRequest{
+//		     Token: token,
+//	  })
+func LimitsContext(ctx context.Context, request LimitsRequest) (*LimitsResponse, error) {
+	return defaultClient.LimitsContext(ctx, request)
+}
+
+// LimitsContext will submit a GET request to the Pushover
+// Limits API with the client's settings. This function will
+// retrieve the monthly message limit of an application and
+// how many messages remain.
+//
+//	resp, err := client.LimitsContext(context.Background(),
+//	  pushover.LimitsRequest{})
+func (c *Client) LimitsContext(ctx context.Context, request LimitsRequest) (*LimitsResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, limitsURL, limitsPath)
+	request.Token = c.token(request.Token)
+
+	query := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LimitsResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	var ok bool
+
+	// Populate limits
+	if r.Limit, ok = api.MapKeyToInt(keyLimit, a.Result); ok {
+		delete(a.Result, keyLimit)
+	}
+
+	if r.Remaining, ok = api.MapKeyToInt(keyRemaining, a.Result); ok {
+		delete(a.Result, keyRemaining)
+	}
+
+	if r.Reset, ok = mapKeyToTime(keyReset, a.Result); ok {
+		delete(a.Result, keyReset)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// Limits will submit a GET request to the Pushover
+// Limits API. This function will retrieve the monthly message
+// limit of an application and how many messages remain.
+//
+//	  resp, err := pushover.Limits(pushover.LimitsRequest{
+//		     Token: token,
+//	  })
+func Limits(request LimitsRequest) (*LimitsResponse, error) {
+	return LimitsContext(context.Background(), request)
+}
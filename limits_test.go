@@ -0,0 +1,100 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+/*
+Limits Valid
+{"limit":10000,"remaining":7496,"reset":1393653600,"status":1,"request":"000000000000000000000000000000000"}
+
+Token Invalid
+{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"5a4e1f6c-4d1b-4a9e-8f3c-0f1f5c7a2e3b"}
+*/
+
+func limitsServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	token := r.Form["token"]
+	if len(token) == 0 || len(token[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token[0] == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"limit":10000,"remaining":7496,"status":1,"request":"%s"`, id)
+		return
+	}
+
+	if token[0] == "failbody" {
+		w.Header().Set("Content-Length", "1")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"limit":10000,"remaining":7496,"reset":1393653600,"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverLimits(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(limitsServerHandler))
+	defer apiServer.Close()
+
+	var request LimitsRequest
+
+	// Default Pushover URL
+	limitsURL = apiServer.URL
+	r, e := LimitsContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	r, e = Limits(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.Limit != 10000 || r.Remaining != 7496 || r.Reset.Unix() != 1393653600 ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = LimitsContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = Limits(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Invalid body
+	request.Token = "failbody"
+	_, e = Limits(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = Limits(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
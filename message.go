@@ -3,20 +3,28 @@ package pushover
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // MessageRequest is the data for the POST to the Pushover
 // REST API. Some fields in this request should contain
 // numbers but the Pushover API parameters are strings.
-// There is no validation performed for these fields. If
-// invalid data is submitted to the Pushover API, it will
-// be rejected with an appropriate error.
+// These fields are only validated by Validate, or before
+// sending when VerifyRequest is set. Otherwise, if invalid
+// data is submitted to the Pushover API, it will be rejected
+// with an appropriate error. TypedMessageRequest is an
+// alternative with typed fields that converts to this request.
 //
 // See the Pushover API documentation for
 // more information on these parameters.
@@ -65,9 +73,28 @@ type MessageRequest struct {
 	//
 	// See the Pushover REST API documentation for valid
 	// values. Invalid sound names will not be rejected by
-	//Pushover
+	// Pushover unless VerifySound is enabled.
 	Sound string
 
+	// Verify Sound against the sounds available to the
+	// application before sending the message
+	//
+	// The sounds are retrieved from the Pushover Sounds API
+	// and kept in DefaultSoundsCache. An unknown sound is
+	// rejected with ErrUnknownSound and the message is not
+	// sent. If Pushover rejects the sounds request, the
+	// message is sent and Pushover reports the error.
+	VerifySound bool
+
+	// The URL of the Pushover Sounds API used by VerifySound
+	//
+	// If empty, the sounds are retrieved from the client's
+	// BaseURL or the Pushover Sounds API. When PushoverURL is
+	// set without a client BaseURL, SoundsURL is required and
+	// the message is rejected with ErrSoundsURLRequired without
+	// it, so the token is not sent to the Pushover Sounds API.
+	SoundsURL string
+
 	// The device to send the message to rather than all the
 	// user's devices.
 	//
@@ -80,7 +107,9 @@ type MessageRequest struct {
 	// See the Pushover REST API documentation for values and
 	// what they mean
 	//
-	// Invalid priority numbers will be rejected by Pushover
+	// Invalid priority numbers will be rejected by Pushover.
+	// The Priority constants give the valid numbers, such as
+	// PriorityEmergency.String().
 	Priority string
 
 	// How often in seconds the Pushover servers will send
@@ -103,6 +132,13 @@ type MessageRequest struct {
 	// Optional be set when Priority is set to "2"
 	Callback string
 
+	// Comma separated list of tags for the message
+	//
+	// Optional when Priority is set to "2". Tagged
+	// notifications can be cancelled together with
+	// CancelByTag.
+	Tags string
+
 	// Unix timestamp for the message rather than the time
 	// the message was received by the Pushover REST API
 	//
@@ -116,6 +152,321 @@ type MessageRequest struct {
 	//
 	// Leave blank to default to image.jpg
 	ImageName string
+
+	// Check the request with Validate before sending it
+	//
+	// A request that fails validation is not sent and the
+	// ValidationError is returned.
+	VerifyRequest bool
+}
+
+// Limits documented for the Pushover Message API
+const (
+	maxMessageLength   = 1024
+	maxTitleLength     = 250
+	maxURLLength       = 512
+	maxURLTitleLength  = 100
+	minEmergencyRetry  = 30
+	maxEmergencyExpire = 10800
+	maxAttachmentSize  = 5 * 1024 * 1024
+)
+
+// Validate checks the request against the constraints
+// documented for the Pushover Message API without sending it.
+// Every failed constraint is returned in a ValidationError.
+//
+// The size of ImageReader is only checked when it has a Len or
+// Stat method, such as a *bytes.Reader or an *os.File.
+//
+//	  err := pushover.MessageRequest{
+//		     Token:   token,
+//		     User:    user,
+//		     Message: message,
+//	  }.Validate()
+func (r MessageRequest) Validate() error {
+	params := make(map[string]string)
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{field: keyToken, value: r.Token},
+		{field: keyUser, value: r.User},
+		{field: keyMessage, value: r.Message},
+	}
+
+	for _, v := range required {
+		if len(v.value) == 0 {
+			params[v.field] = "cannot be blank"
+		}
+	}
+
+	lengths := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{field: keyMessage, value: r.Message, max: maxMessageLength},
+		{field: keyTitle, value: r.Title, max: maxTitleLength},
+		{field: keyURL, value: r.URL, max: maxURLLength},
+		{field: keyURLTitle, value: r.URLTitle, max: maxURLTitleLength},
+	}
+
+	for _, v := range lengths {
+		if utf8.RuneCountInString(v.value) > v.max {
+			params[v.field] = fmt.Sprintf("cannot be more than %d characters", v.max)
+		}
+	}
+
+	if flagEnabled(r.HTML) && flagEnabled(r.Monospace) {
+		params[keyHTML] = "cannot be set with monospace"
+		params[keyMonospace] = "cannot be set with html"
+	}
+
+	var priority int
+	if len(r.Priority) > 0 {
+		var err error
+		if priority, err = strconv.Atoi(r.Priority); err != nil || priority < -2 || priority > 2 {
+			params[keyPriority] = "is invalid, can only be -2, -1, 0, 1, or 2"
+		}
+	}
+
+	if priority == 2 {
+		if retry, err := strconv.Atoi(r.Retry); err != nil || retry < minEmergencyRetry {
+			params[keyRetry] = fmt.Sprintf("must be at least %d seconds with priority 2", minEmergencyRetry)
+		}
+
+		if expire, err := strconv.Atoi(r.Expire); err != nil || expire < 1 || expire > maxEmergencyExpire {
+			params[keyExpire] = fmt.Sprintf("must be from 1 to %d seconds with priority 2", maxEmergencyExpire)
+		}
+	}
+
+	if len(r.Timestamp) > 0 {
+		if _, err := strconv.ParseInt(r.Timestamp, 10, 64); err != nil {
+			params[keyTimestamp] = "must be a Unix timestamp"
+		}
+	}
+
+	if size, ok := readerSize(r.ImageReader); ok && size > maxAttachmentSize {
+		params[keyAttachment] = fmt.Sprintf("cannot be more than %d bytes", maxAttachmentSize)
+	}
+
+	if len(params) == 0 {
+		return nil
+	}
+
+	fieldErrors := make([]string, 0, len(params))
+	for k, v := range params {
+		fieldErrors = append(fieldErrors, k+" "+v)
+	}
+	sort.Strings(fieldErrors)
+
+	return &ValidationError{Errors: fieldErrors, ErrorParameters: params}
+}
+
+// flagEnabled reports whether a "1" valued option such as HTML
+// is enabled
+func flagEnabled(value string) bool {
+	return len(value) > 0 && value != "0"
+}
+
+// readerSize returns the number of bytes left in a reader if
+// it can be found without reading
+func readerSize(reader io.Reader) (int64, bool) {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len()), true
+	case interface{ Stat() (os.FileInfo, error) }:
+		if info, err := r.Stat(); err == nil {
+			return info.Size(), true
+		}
+	}
+
+	return 0, false
+}
+
+// Priority is the priority of a message. See the Pushover API
+// documentation for how each priority is delivered.
+type Priority int
+
+// Message priorities
+const (
+	// PriorityLowest generates no notification or alert
+	PriorityLowest Priority = -2
+
+	// PriorityLow sends a quiet notification
+	PriorityLow Priority = -1
+
+	// PriorityNormal is the default priority
+	PriorityNormal Priority = 0
+
+	// PriorityHigh bypasses the user's quiet hours
+	PriorityHigh Priority = 1
+
+	// PriorityEmergency repeats the notification until it is
+	// acknowledged. Retry and Expire are required.
+	PriorityEmergency Priority = 2
+)
+
+// String returns the priority in the form used by the
+// Priority field of MessageRequest
+func (p Priority) String() string {
+	return strconv.Itoa(int(p))
+}
+
+// TypedMessageRequest is an alternative to MessageRequest
+// with typed fields in place of the numeric and "1" valued
+// strings. It is converted to a MessageRequest for sending
+// with MessageRequest.
+//
+//	  resp, err := pushover.Message(pushover.TypedMessageRequest{
+//		     Token:    token,
+//		     User:     user,
+//		     Message:  message,
+//		     Priority: pushover.PriorityEmergency,
+//		     Retry:    time.Minute,
+//		     Expire:   3 * time.Hour,
+//	  }.MessageRequest())
+type TypedMessageRequest struct {
+	// The URL for the Pushover REST API POST.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// The user's token for message delivery
+	User string
+
+	// The message sent to the user
+	Message string
+
+	// Optional Fields
+
+	// Message title
+	Title string
+
+	// Embedded URL
+	URL string
+
+	// The displayed text for the URL
+	URLTitle string
+
+	// Enable HTML formatting of the message
+	//
+	// Cannot be enabled together with Monospace
+	HTML bool
+
+	// Enable monospace formatting of the message
+	Monospace bool
+
+	// Sound name for the sound on the user's device
+	Sound string
+
+	// Verify Sound against the sounds available to the
+	// application before sending the message
+	VerifySound bool
+
+	// The URL of the Pushover Sounds API used by VerifySound
+	SoundsURL string
+
+	// The devices to send the message to rather than all the
+	// user's devices
+	Devices []string
+
+	// Priority of the message
+	Priority Priority
+
+	// How often the Pushover servers will send the same
+	// notification to the user
+	//
+	// Sent in whole seconds, rounded up. Must be at least 30
+	// seconds when Priority is PriorityEmergency.
+	Retry time.Duration
+
+	// How long the notification will continue to be retried
+	//
+	// Sent in whole seconds, rounded up. Must be at most 3
+	// hours when Priority is PriorityEmergency.
+	Expire time.Duration
+
+	// Callback url for the message
+	Callback string
+
+	// Tags for cancelling the message with CancelByTag
+	Tags []string
+
+	// Time of the message rather than the time the message
+	// was received by the Pushover REST API
+	Timestamp time.Time
+
+	// Reader for image (attachment) data
+	ImageReader io.Reader
+
+	// Optional image name
+	ImageName string
+
+	// Check the request with Validate before sending it
+	VerifyRequest bool
+}
+
+// MessageRequest converts the request to a MessageRequest with
+// the fields in the form used by the Pushover API. Zero values
+// are left empty.
+func (t TypedMessageRequest) MessageRequest() MessageRequest {
+	r := MessageRequest{
+		PushoverURL:   t.PushoverURL,
+		Token:         t.Token,
+		User:          t.User,
+		Message:       t.Message,
+		Title:         t.Title,
+		URL:           t.URL,
+		URLTitle:      t.URLTitle,
+		Sound:         t.Sound,
+		VerifySound:   t.VerifySound,
+		SoundsURL:     t.SoundsURL,
+		Device:        strings.Join(t.Devices, ","),
+		Callback:      t.Callback,
+		Tags:          strings.Join(t.Tags, ","),
+		ImageReader:   t.ImageReader,
+		ImageName:     t.ImageName,
+		VerifyRequest: t.VerifyRequest,
+	}
+
+	if t.HTML {
+		r.HTML = "1"
+	}
+
+	if t.Monospace {
+		r.Monospace = "1"
+	}
+
+	if t.Priority != PriorityNormal {
+		r.Priority = t.Priority.String()
+	}
+
+	if t.Retry > 0 {
+		r.Retry = durationToSeconds(t.Retry)
+	}
+
+	if t.Expire > 0 {
+		r.Expire = durationToSeconds(t.Expire)
+	}
+
+	if !t.Timestamp.IsZero() {
+		r.Timestamp = strconv.FormatInt(t.Timestamp.Unix(), 10)
+	}
+
+	return r
+}
+
+// durationToSeconds converts a duration to whole seconds,
+// rounding up so a duration under a second is not sent as 0
+func durationToSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10)
 }
 
 // MessageResponse is the response from this API. It is read from
@@ -150,6 +501,24 @@ type MessageResponse struct {
 	// is returned
 	Receipt string
 
+	// Number of messages the application may send each
+	// month, from the X-Limit-App-Limit header
+	//
+	// Zero if the header was not returned
+	AppLimit int
+
+	// Number of messages remaining this month, from the
+	// X-Limit-App-Remaining header
+	//
+	// Zero if the header was not returned
+	AppRemaining int
+
+	// Time the remaining count is reset to the limit, from
+	// the X-Limit-App-Reset header
+	//
+	// Zero if the header was not returned
+	AppReset time.Time
+
 	// List of errors returned
 	//
 	// Empty if no errors
@@ -173,17 +542,63 @@ type MessageResponse struct {
 //		     Message: message,
 //	  })
 func MessageContext(ctx context.Context, request MessageRequest) (*MessageResponse, error) {
+	return defaultClient.MessageContext(ctx, request)
+}
+
+// MessageContext will submit a request to the Pushover
+// Message API with the client's settings. This function will
+// send a message, triggering a notification on a user's
+// device or a group's devices.
+//
+//	  resp, err := client.MessageContext(context.Background(),
+//	    pushover.MessageRequest{
+//		     User:    user,
+//		     Message: message,
+//	  })
+func (c *Client) MessageContext(ctx context.Context, request MessageRequest) (*MessageResponse, error) {
 	var requestData io.Reader
 	var contentType string
 
-	if len(request.PushoverURL) == 0 {
-		request.PushoverURL = messagesURL
+	verifySound := request.VerifySound && len(request.Sound) > 0
+
+	// Verify the sound for a stand-in message URL only at a
+	// stand-in sounds URL
+	if verifySound && len(request.SoundsURL) == 0 && len(request.PushoverURL) > 0 && len(c.BaseURL) == 0 {
+		return nil, &ErrSoundsURLRequired{}
+	}
+
+	request.PushoverURL = c.endpoint(request.PushoverURL, messagesURL, messagesPath)
+	request.Token = c.token(request.Token)
+
+	if request.VerifyRequest {
+		if err := request.Validate(); err != nil {
+			return nil, err
+		}
 	}
 
 	if len(request.ImageName) == 0 {
 		request.ImageName = "image.jpg"
 	}
 
+	if verifySound {
+		soundsRequest := SoundsRequest{PushoverURL: request.SoundsURL, Token: request.Token}
+		if len(soundsRequest.PushoverURL) == 0 && len(c.BaseURL) > 0 {
+			soundsRequest.PushoverURL = c.endpoint("", soundsURL, soundsPath)
+		}
+
+		// A rejected sounds request is reported by the message
+		// request instead
+		var apiErr *APIError
+		sounds, err := DefaultSoundsCache.soundsContext(ctx, c, soundsRequest)
+		if err != nil && !errors.As(err, &apiErr) {
+			return nil, err
+		}
+
+		if _, ok := sounds.Sounds[request.Sound]; err == nil && !ok {
+			return nil, &ErrUnknownSound{Sound: request.Sound}
+		}
+	}
+
 	fields := []struct {
 		field string
 		value string
@@ -202,6 +617,7 @@ func MessageContext(ctx context.Context, request MessageRequest) (*MessageRespon
 		{field: keyRetry, value: request.Retry},
 		{field: keyExpire, value: request.Expire},
 		{field: keyCallback, value: request.Callback},
+		{field: keyTags, value: request.Tags},
 		{field: keyTimestamp, value: request.Timestamp},
 	}
 
@@ -218,7 +634,7 @@ func MessageContext(ctx context.Context, request MessageRequest) (*MessageRespon
 	} else {
 		requestBody := &bytes.Buffer{}
 		writer := multipart.NewWriter(requestBody)
-		part, _ := writer.CreateFormFile("attachment", request.ImageName)
+		part, _ := writer.CreateFormFile(keyAttachment, request.ImageName)
 		_, _ = io.Copy(part, request.ImageReader)
 
 		for _, v := range fields {
@@ -237,67 +653,45 @@ func MessageContext(ctx context.Context, request MessageRequest) (*MessageRespon
 		return nil, &ErrInvalidRequest{}
 	}
 
-	req = req.WithContext(ctx)
 	req.Header.Set("Content-Type", contentType)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.transport().Do(ctx, req)
 	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	r := new(MessageResponse)
-
-	body := &bytes.Buffer{}
-	_, err = body.ReadFrom(resp.Body)
+	a, err := readResponse(resp)
 	if err != nil {
-		return nil, &ErrInvalidResponse{}
+		return nil, err
 	}
 
-	r.ResponseBody = body.String()
-	r.HTTPStatus = resp.Status
-	r.HTTPStatusCode = resp.StatusCode
-
-	// Decode json response
-	var result map[string]interface{}
-	if e := json.NewDecoder(strings.NewReader(string(r.ResponseBody))).Decode(&result); e != nil {
-		return nil, &ErrInvalidResponse{}
+	r := &MessageResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
 	}
 
-	var ok bool
-
-	// Populate request status
-	if r.APIStatus, ok = mapKeyToInt(keyStatus, result); !ok {
-		return nil, &ErrInvalidResponse{}
+	// Populate application limits from headers
+	r.AppLimit, _ = headerToInt(headerAppLimit, resp.Header)
+	r.AppRemaining, _ = headerToInt(headerAppRemaining, resp.Header)
+	if reset, ok := headerToInt(headerAppReset, resp.Header); ok {
+		r.AppReset = time.Unix(int64(reset), 0)
 	}
-	delete(result, keyStatus)
 
-	// Populate request ID
-	if r.Request, ok = result[keyRequest].(string); !ok {
-		return nil, &ErrInvalidResponse{}
-	}
-	delete(result, keyRequest)
+	var ok bool
 
 	// Populate receipt
-	if r.Receipt, ok = result[keyReceipt].(string); ok {
-		delete(result, keyReceipt)
+	if r.Receipt, ok = a.Result[keyReceipt].(string); ok {
+		delete(a.Result, keyReceipt)
 	}
 
-	// Populate errors
-	r.Errors = interfaceArrayToStringArray(keyErrors, result)
-	delete(result, keyErrors)
-
-	// Populate parameters with corresponding errors
-	r.ErrorParameters = interfaceMapToStringMap(result)
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
 
-	return r, nil
+	return r, a.apiError(r.Errors, r.ErrorParameters)
 }
 
 // Message will submit a request to the Pushover
@@ -1,10 +1,17 @@
 package pushover
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -86,9 +93,15 @@ func serverHandler(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(w, `{"priority":"is invalid, can only be -2, -1, 0, 1, or 2","errors":["priority is invalid"],"status":0,"request":"%s"}`, id)
 			return
 		} else if priority == 2 {
+			// Echo tags as the receipt to verify they were sent
+			receipt := "1337"
+			if tags := r.Form["tags"]; len(tags) > 0 {
+				receipt = tags[0]
+			}
+
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `{"status":1,"request":"%s","receipt":"1337"}`, id)
+			fmt.Fprintf(w, `{"status":1,"request":"%s","receipt":"%s"}`, id, receipt)
 			return
 		}
 	}
@@ -120,6 +133,12 @@ func serverHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if user[0] == "limits" {
+		w.Header().Set("X-Limit-App-Limit", "10000")
+		w.Header().Set("X-Limit-App-Remaining", "7496")
+		w.Header().Set("X-Limit-App-Reset", "1393653600")
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
@@ -198,6 +217,19 @@ func TestPushoverMessage(t *testing.T) {
 		t.Error("Valid submit data")
 	}
 
+	// No application limit headers
+	if r.AppLimit != 0 || r.AppRemaining != 0 || !r.AppReset.IsZero() {
+		t.Error("No application limit headers")
+	}
+
+	// Application limit headers
+	request.User = "limits"
+	r, _ = Message(request)
+	if r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 ||
+		r.AppLimit != 10000 || r.AppRemaining != 7496 || r.AppReset.Unix() != 1393653600 {
+		t.Error("Application limit headers")
+	}
+
 	// Invalid API Status in response
 	request.User = "failstatus"
 	_, e = Message(request)
@@ -250,7 +282,15 @@ func TestPushoverMessage(t *testing.T) {
 		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
 		t.Error("Priority 2")
 	}
+
+	// Tags are submitted with priority of 2
+	request.Tags = "incident"
+	r, _ = Message(request)
+	if r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Receipt != "incident" {
+		t.Error("Tags")
+	}
 	request.Priority = "0"
+	request.Tags = ""
 
 	// Context cancellation
 	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
@@ -268,6 +308,15 @@ func TestPushoverMessage(t *testing.T) {
 		t.Error("Image attachment")
 	}
 
+	// Tags are submitted with image attachment
+	request.ImageReader = strings.NewReader("image data")
+	request.Priority = "2"
+	request.Tags = "incident"
+	r, _ = Message(request)
+	if r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Receipt != "incident" {
+		t.Error("Tags with image attachment")
+	}
+
 	// Test http.PostForm() returning error
 	apiServer.Close()
 	_, e = Message(request)
@@ -275,3 +324,271 @@ func TestPushoverMessage(t *testing.T) {
 		t.Error("No API server")
 	}
 }
+
+func TestMessageValidate(t *testing.T) {
+	valid := MessageRequest{Token: "testtoken", User: "testuser", Message: "message"}
+
+	tests := []struct {
+		name   string
+		modify func(r *MessageRequest)
+		params map[string]string
+	}{
+		{"Valid", func(r *MessageRequest) {}, nil},
+		{"Missing required", func(r *MessageRequest) { *r = MessageRequest{} }, map[string]string{
+			"token":   "cannot be blank",
+			"user":    "cannot be blank",
+			"message": "cannot be blank",
+		}},
+		{"Lengths at limit", func(r *MessageRequest) {
+			r.Message = strings.Repeat("é", 1024)
+			r.Title = strings.Repeat("t", 250)
+			r.URL = strings.Repeat("u", 512)
+			r.URLTitle = strings.Repeat("t", 100)
+		}, nil},
+		{"Lengths over limit", func(r *MessageRequest) {
+			r.Message = strings.Repeat("é", 1025)
+			r.Title = strings.Repeat("t", 251)
+			r.URL = strings.Repeat("u", 513)
+			r.URLTitle = strings.Repeat("t", 101)
+		}, map[string]string{
+			"message":   "cannot be more than 1024 characters",
+			"title":     "cannot be more than 250 characters",
+			"url":       "cannot be more than 512 characters",
+			"url_title": "cannot be more than 100 characters",
+		}},
+		{"HTML and monospace", func(r *MessageRequest) { r.HTML = "1"; r.Monospace = "1" }, map[string]string{
+			"html":      "cannot be set with monospace",
+			"monospace": "cannot be set with html",
+		}},
+		{"HTML without monospace", func(r *MessageRequest) { r.HTML = "1"; r.Monospace = "0" }, nil},
+		{"Priority out of range", func(r *MessageRequest) { r.Priority = "3" }, map[string]string{
+			"priority": "is invalid, can only be -2, -1, 0, 1, or 2",
+		}},
+		{"Priority not a number", func(r *MessageRequest) { r.Priority = "high" }, map[string]string{
+			"priority": "is invalid, can only be -2, -1, 0, 1, or 2",
+		}},
+		{"Priority 2 without retry and expire", func(r *MessageRequest) { r.Priority = "2" }, map[string]string{
+			"retry":  "must be at least 30 seconds with priority 2",
+			"expire": "must be from 1 to 10800 seconds with priority 2",
+		}},
+		{"Priority 2 out of range", func(r *MessageRequest) { r.Priority = "2"; r.Retry = "29"; r.Expire = "10801" }, map[string]string{
+			"retry":  "must be at least 30 seconds with priority 2",
+			"expire": "must be from 1 to 10800 seconds with priority 2",
+		}},
+		{"Priority 2 expire not seconds", func(r *MessageRequest) { r.Priority = "2"; r.Retry = "30"; r.Expire = "3h" }, map[string]string{
+			"expire": "must be from 1 to 10800 seconds with priority 2",
+		}},
+		{"Priority 2", func(r *MessageRequest) { r.Priority = "2"; r.Retry = "30"; r.Expire = "10800" }, nil},
+		{"Retry without priority 2", func(r *MessageRequest) { r.Priority = "1"; r.Retry = "1" }, nil},
+		{"Timestamp", func(r *MessageRequest) { r.Timestamp = "1393653600" }, nil},
+		{"Timestamp not a number", func(r *MessageRequest) { r.Timestamp = "yesterday" }, map[string]string{
+			"timestamp": "must be a Unix timestamp",
+		}},
+		{"Attachment at limit", func(r *MessageRequest) {
+			r.ImageReader = bytes.NewReader(make([]byte, 5*1024*1024))
+		}, nil},
+		{"Attachment over limit", func(r *MessageRequest) {
+			r.ImageReader = bytes.NewReader(make([]byte, 5*1024*1024+1))
+		}, map[string]string{
+			"attachment": "cannot be more than 5242880 bytes",
+		}},
+		{"Attachment of unknown size", func(r *MessageRequest) {
+			r.ImageReader = io.LimitReader(bytes.NewReader(make([]byte, 5*1024*1024+1)), 5*1024*1024+1)
+		}, nil},
+	}
+
+	for _, test := range tests {
+		request := valid
+		test.modify(&request)
+
+		e := request.Validate()
+		if test.params == nil {
+			if e != nil {
+				t.Errorf("%s: %v", test.name, e)
+			}
+			continue
+		}
+
+		var ve *ValidationError
+		if !errors.As(e, &ve) || !reflect.DeepEqual(ve.ErrorParameters, test.params) || len(ve.Errors) != len(test.params) ||
+			!sort.StringsAreSorted(ve.Errors) || !strings.HasPrefix(e.Error(), "Invalid request: ") {
+			t.Errorf("%s: %v", test.name, e)
+		}
+	}
+}
+
+func TestMessageValidateFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "image.jpg")
+	if err := os.WriteFile(name, make([]byte, 5*1024*1024+1), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	request := MessageRequest{Token: "testtoken", User: "testuser", Message: "message", ImageReader: f}
+	var ve *ValidationError
+	if e := request.Validate(); !errors.As(e, &ve) || len(ve.ErrorParameters["attachment"]) == 0 {
+		t.Error("Attachment file over limit")
+	}
+
+	// Closed file size is unknown
+	f.Close()
+	if e := request.Validate(); e != nil {
+		t.Error("Closed attachment file")
+	}
+}
+
+func TestMessageVerifyRequest(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverHandler))
+	defer apiServer.Close()
+
+	request := MessageRequest{
+		PushoverURL:   apiServer.URL,
+		User:          "testuser",
+		Message:       "message",
+		Priority:      "2",
+		Retry:         "30",
+		Expire:        "3h",
+		VerifyRequest: true,
+	}
+
+	// Invalid request is not sent
+	r, e := Message(request)
+	var ve *ValidationError
+	if r != nil || !errors.As(e, &ve) || ve.ErrorParameters["token"] != "cannot be blank" ||
+		ve.ErrorParameters["expire"] != "must be from 1 to 10800 seconds with priority 2" {
+		t.Error("Invalid request")
+	}
+
+	// Token from the client is validated
+	client := &Client{Token: "testtoken"}
+	request.Expire = "10800"
+	r, e = client.MessageContext(context.TODO(), request)
+	if e != nil || r.APIStatus != 1 {
+		t.Error("Valid request")
+	}
+
+	// Invalid request is sent without verification
+	request.VerifyRequest = false
+	request.Expire = "3h"
+	r, e = client.MessageContext(context.TODO(), request)
+	if e != nil || r.APIStatus != 1 {
+		t.Error("Invalid request without verification")
+	}
+}
+
+func TestPriority(t *testing.T) {
+	priorities := map[Priority]string{
+		PriorityLowest:    "-2",
+		PriorityLow:       "-1",
+		PriorityNormal:    "0",
+		PriorityHigh:      "1",
+		PriorityEmergency: "2",
+	}
+
+	for p, s := range priorities {
+		if p.String() != s {
+			t.Errorf("Priority %d", int(p))
+		}
+	}
+}
+
+func TestTypedMessageRequest(t *testing.T) {
+	image := strings.NewReader("image data")
+	timestamp := time.Unix(1393653600, 0)
+
+	// Typed fields are converted
+	r := TypedMessageRequest{
+		PushoverURL:   "url",
+		Token:         "testtoken",
+		User:          "testuser",
+		Message:       "message",
+		Title:         "title",
+		URL:           "https://example.com",
+		URLTitle:      "urlTitle",
+		HTML:          true,
+		Sound:         "siren",
+		VerifySound:   true,
+		SoundsURL:     "soundsURL",
+		Devices:       []string{"phone", "tablet"},
+		Priority:      PriorityEmergency,
+		Retry:         90*time.Second + 500*time.Millisecond,
+		Expire:        3 * time.Hour,
+		Callback:      "https://example.com/callback",
+		Tags:          []string{"incident", "db"},
+		Timestamp:     timestamp,
+		ImageReader:   image,
+		ImageName:     "image.png",
+		VerifyRequest: true,
+	}.MessageRequest()
+
+	expected := MessageRequest{
+		PushoverURL:   "url",
+		Token:         "testtoken",
+		User:          "testuser",
+		Message:       "message",
+		Title:         "title",
+		URL:           "https://example.com",
+		URLTitle:      "urlTitle",
+		HTML:          "1",
+		Sound:         "siren",
+		VerifySound:   true,
+		SoundsURL:     "soundsURL",
+		Device:        "phone,tablet",
+		Priority:      "2",
+		Retry:         "91",
+		Expire:        "10800",
+		Callback:      "https://example.com/callback",
+		Tags:          "incident,db",
+		Timestamp:     "1393653600",
+		ImageReader:   image,
+		ImageName:     "image.png",
+		VerifyRequest: true,
+	}
+
+	if !reflect.DeepEqual(r, expected) || r.Validate() != nil {
+		t.Errorf("Typed fields: %+v", r)
+	}
+
+	// Durations are rounded up to whole seconds
+	for d, seconds := range map[time.Duration]string{
+		time.Nanosecond:                    "1",
+		500 * time.Millisecond:             "1",
+		time.Second:                        "1",
+		time.Second + time.Nanosecond:      "2",
+		3*time.Hour + 500*time.Millisecond: "10801",
+	} {
+		r = TypedMessageRequest{Retry: d, Expire: d}.MessageRequest()
+		if r.Retry != seconds || r.Expire != seconds {
+			t.Errorf("Duration %v: retry %q, expire %q", d, r.Retry, r.Expire)
+		}
+	}
+
+	// Zero values are left empty
+	r = TypedMessageRequest{Token: "testtoken", User: "testuser", Message: "message", Monospace: true}.MessageRequest()
+	if r != (MessageRequest{Token: "testtoken", User: "testuser", Message: "message", Monospace: "1"}) {
+		t.Errorf("Zero values: %+v", r)
+	}
+
+	// Converted request is accepted
+	apiServer := httptest.NewServer(http.HandlerFunc(serverHandler))
+	defer apiServer.Close()
+
+	resp, e := Message(TypedMessageRequest{
+		PushoverURL: apiServer.URL,
+		Token:       "testtoken",
+		User:        "testuser",
+		Message:     "message",
+		Priority:    PriorityEmergency,
+		Retry:       time.Minute,
+		Expire:      time.Hour,
+		Tags:        []string{"incident"},
+	}.MessageRequest())
+	if e != nil || resp.Receipt != "incident" {
+		t.Error("Typed message")
+	}
+}
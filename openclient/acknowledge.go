@@ -0,0 +1,93 @@
+package openclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// AcknowledgeMessageResponse is the response from the Pushover
+// Open Client API that acknowledges emergency priority messages.
+// It is read from the body of the Pushover REST API response
+// and translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type AcknowledgeMessageResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// AcknowledgeMessage will submit a POST request to the Pushover
+// Open Client API. This function will acknowledge an emergency
+// (priority 2) message by its receipt, stopping its retries.
+// The receipt is in the Receipt field of the ReceivedMessage,
+// or in the MessageResponse of the message's sender.
+//
+//	resp, err := openclient.AcknowledgeMessage(context.Background(),
+//	  secret, receipt)
+func AcknowledgeMessage(ctx context.Context, secret, receipt string) (*AcknowledgeMessageResponse, error) {
+	return defaultClient.AcknowledgeMessage(ctx, secret, receipt)
+}
+
+// AcknowledgeMessage will submit a POST request to the
+// Pushover Open Client API with the client's settings. This
+// function will acknowledge an emergency (priority 2) message
+// by its receipt.
+//
+//	resp, err := client.AcknowledgeMessage(context.Background(),
+//	  secret, receipt)
+func (c *Client) AcknowledgeMessage(ctx context.Context, secret, receipt string) (*AcknowledgeMessageResponse, error) {
+	formData := url.Values{
+		keySecret: {secret},
+	}
+
+	resp, err := c.transport().PostForm(ctx, c.endpoint("/receipts/"+url.PathEscape(receipt)+"/acknowledge.json"), formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &AcknowledgeMessageResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
@@ -0,0 +1,101 @@
+package openclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+)
+
+/*
+Acknowledge Valid
+{"status":1,"request":"7b6a5f4e-3d2c-4b1a-9f8e-7d6c5b4a3f2e"}
+
+Acknowledge Invalid Receipt
+{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"1f2e3d4c-5b6a-4978-8695-a4b3c2d1e0f9"}
+*/
+
+func acknowledgeServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/acknowledge.json") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Form.Get("secret") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	receipt := strings.TrimSuffix(r.URL.Path, "/acknowledge.json")
+	receipt = receipt[strings.LastIndex(receipt, "/")+1:]
+
+	switch receipt {
+	case "receipt":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+	case "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"`, id)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"%s"}`, id)
+	}
+}
+
+func TestAcknowledgeMessage(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(acknowledgeServerHandler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: &pushover.Client{BaseURL: apiServer.URL}}
+
+	// Valid acknowledgement
+	r, e := client.AcknowledgeMessage(context.TODO(), "secret", "receipt")
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid acknowledgement")
+	}
+
+	// Unknown receipt
+	r, e = client.AcknowledgeMessage(context.TODO(), "secret", "unknown")
+	if !errors.As(e, new(*pushover.APIError)) || r.HTTPStatusCode != http.StatusNotFound || r.APIStatus != 0 ||
+		r.Errors[0] != "receipt not found; may be invalid or expired" || r.ErrorParameters["receipt"] != "not found" {
+		t.Error("Unknown receipt")
+	}
+
+	// Invalid secret
+	r, e = client.AcknowledgeMessage(context.TODO(), "invalid", "receipt")
+	if !errors.As(e, new(*pushover.APIError)) || r.APIStatus != 0 || r.ErrorParameters["secret"] != "invalid" {
+		t.Error("Invalid secret")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = client.AcknowledgeMessage(ctx, "secret", "receipt")
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	_, e = client.AcknowledgeMessage(context.TODO(), "secret", "failjson")
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = client.AcknowledgeMessage(context.TODO(), "secret", "receipt")
+	if e == nil {
+		t.Error("No API server")
+	}
+}
@@ -0,0 +1,141 @@
+package openclient
+
+import (
+	"context"
+	"net/url"
+)
+
+// maxDeviceNameLength is the longest device name accepted
+// by Pushover
+const maxDeviceNameLength = 25
+
+// deviceOS is the operating system sent when registering a
+// device. Pushover uses "O" for Open Client devices.
+const deviceOS = "O"
+
+// RegisterDeviceResponse is the response from the Pushover
+// Open Client device registration API. It is read from the body
+// of the Pushover REST API response and translated to this
+// response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type RegisterDeviceResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// The device's ID, used with the user's secret for the
+	// message APIs. It should be stored with the secret.
+	ID string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// ValidateDeviceName checks a device name against the Pushover
+// rules for device names. A name must be 1 to 25 characters
+// long and contain only letters, numbers, underscores and
+// dashes. An ErrInvalidDeviceName is returned for a name that
+// breaks a rule.
+func ValidateDeviceName(name string) error {
+	if len(name) == 0 {
+		return &ErrInvalidDeviceName{Name: name, Reason: "name is empty"}
+	}
+
+	if len(name) > maxDeviceNameLength {
+		return &ErrInvalidDeviceName{Name: name, Reason: "name is longer than 25 characters"}
+	}
+
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return &ErrInvalidDeviceName{Name: name, Reason: "name may only contain letters, numbers, _ and -"}
+		}
+	}
+
+	return nil
+}
+
+// RegisterDevice will submit a POST request to the Pushover
+// Open Client API. This function will register a new desktop
+// device for the user with the secret returned by Login.
+//
+// The device name is checked with ValidateDeviceName before
+// the request is submitted. Names rejected by Pushover, such as
+// a name already in use, are returned in the response errors.
+//
+//	resp, err := openclient.RegisterDevice(context.Background(),
+//	  secret, "workstation")
+func RegisterDevice(ctx context.Context, secret, name string) (*RegisterDeviceResponse, error) {
+	return defaultClient.RegisterDevice(ctx, secret, name)
+}
+
+// RegisterDevice will submit a POST request to the Pushover
+// Open Client API with the client's settings. This function
+// will register a new desktop device for the user.
+//
+//	resp, err := client.RegisterDevice(context.Background(),
+//	  secret, "workstation")
+func (c *Client) RegisterDevice(ctx context.Context, secret, name string) (*RegisterDeviceResponse, error) {
+	if err := ValidateDeviceName(name); err != nil {
+		return nil, err
+	}
+
+	formData := url.Values{
+		keySecret: {secret},
+		keyName:   {name},
+		keyOS:     {deviceOS},
+	}
+
+	resp, err := c.transport().PostForm(ctx, c.endpoint("/devices.json"), formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RegisterDeviceResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate device ID
+	var ok bool
+	if r.ID, ok = a.Result[keyID].(string); ok {
+		delete(a.Result, keyID)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
@@ -0,0 +1,130 @@
+package openclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+)
+
+/*
+Register Valid
+{"id":"ikbgpb5eie1b68omnjdfcvc8myvqkh","status":1,"request":"2c9b3d1e-8f7a-4b6c-9d5e-4f3a2b1c0d9e"}
+
+Register Name Taken
+{"errors":{"name":["has already been taken"]},"status":0,"request":"8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5968"}
+
+Register Invalid Secret
+{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+*/
+
+func deviceServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/devices.json") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Form.Get("secret") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	switch r.Form.Get("name") {
+	case "taken":
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"errors":{"name":["has already been taken","is reserved"],"os":"is invalid"},"status":0,"request":"%s"}`, id)
+	case "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"id":"device","status":1,"request":"%s"`, id)
+	default:
+		if r.Form.Get("os") != "O" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"errors":{"os":["is invalid"]},"status":0,"request":"%s"}`, id)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"id":"ikbgpb5eie1b68omnjdfcvc8myvqkh","status":1,"request":"%s"}`, id)
+	}
+}
+
+func TestValidateDeviceName(t *testing.T) {
+	for _, name := range []string{"a", "workstation", "Work_Station-2", strings.Repeat("x", 25)} {
+		if err := ValidateDeviceName(name); err != nil {
+			t.Errorf("Valid name %q: %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", strings.Repeat("x", 26), "work station", "café", "work.station"} {
+		err := ValidateDeviceName(name)
+		if dn, ok := err.(*ErrInvalidDeviceName); !ok || dn.Name != name || len(dn.Reason) == 0 || len(dn.Error()) == 0 {
+			t.Errorf("Invalid name %q", name)
+		}
+	}
+}
+
+func TestRegisterDevice(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(deviceServerHandler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: &pushover.Client{BaseURL: apiServer.URL}}
+
+	// Valid registration
+	r, e := client.RegisterDevice(context.TODO(), "secret", "workstation")
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.ID != "ikbgpb5eie1b68omnjdfcvc8myvqkh" || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid registration")
+	}
+
+	// Invalid name is not submitted
+	r, e = client.RegisterDevice(context.TODO(), "secret", "work station")
+	if _, ok := e.(*ErrInvalidDeviceName); !ok || r != nil {
+		t.Error("Invalid name")
+	}
+
+	// Name rejected by Pushover
+	r, e = client.RegisterDevice(context.TODO(), "secret", "taken")
+	if !errors.As(e, new(*pushover.APIError)) || r.APIStatus != 0 || len(r.ID) > 0 || len(r.Errors) != 3 ||
+		r.Errors[0] != "name has already been taken" || r.Errors[2] != "os is invalid" ||
+		r.ErrorParameters["name"] != "has already been taken, is reserved" || r.ErrorParameters["os"] != "is invalid" {
+		t.Error("Name rejected")
+	}
+
+	// Invalid secret
+	r, e = client.RegisterDevice(context.TODO(), "invalid", "workstation")
+	if !errors.As(e, new(*pushover.APIError)) || r.APIStatus != 0 || r.Errors[0] != "secret is invalid; please log in again" ||
+		r.ErrorParameters["secret"] != "invalid" {
+		t.Error("Invalid secret")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = client.RegisterDevice(ctx, "secret", "workstation")
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	_, e = client.RegisterDevice(context.TODO(), "secret", "failjson")
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = client.RegisterDevice(context.TODO(), "secret", "workstation")
+	if e == nil {
+		t.Error("No API server")
+	}
+}
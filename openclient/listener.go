@@ -0,0 +1,370 @@
+package openclient
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/arcanericky/pushover"
+	"golang.org/x/net/websocket"
+)
+
+// Frames sent by the Pushover Open Client WebSocket server
+const (
+	frameKeepAlive      = '#'
+	frameNewMessage     = '!'
+	frameReload         = 'R'
+	frameError          = 'E'
+	frameAnotherSession = 'A'
+)
+
+const (
+	defaultMinBackoff       = time.Second
+	defaultMaxBackoff       = 5 * time.Minute
+	defaultKeepAliveTimeout = 90 * time.Second
+)
+
+// errReload is returned by a session when the server asks the
+// client to reconnect
+var errReload = errors.New("reload requested")
+
+// ErrSessionFailed indicates the Pushover WebSocket server
+// rejected the login, or Pushover rejected the download of the
+// device's messages. The user must log in and register the
+// device again.
+type ErrSessionFailed struct {
+	// The error returned for the rejected download, or nil if
+	// the WebSocket server rejected the login
+	Err error
+}
+
+func (sf *ErrSessionFailed) Error() string {
+	return "Session failed; log in and register the device again"
+}
+
+// Unwrap returns the error for the rejected download
+func (sf *ErrSessionFailed) Unwrap() error {
+	return sf.Err
+}
+
+// ErrAnotherSession indicates another client logged in with the
+// same device and this session was closed
+type ErrAnotherSession struct{}
+
+func (as *ErrAnotherSession) Error() string {
+	return "Another session logged in with this device"
+}
+
+// Listener receives the messages sent to a device in real time
+// with the Pushover Open Client WebSocket. New messages are
+// downloaded as soon as Pushover signals them and delivered on
+// a channel.
+//
+// Messages are not deleted from the device by the Listener.
+// Delete them with DeleteMessages once they are handled.
+type Listener struct {
+	// Required fields
+
+	// The user's secret returned by Login
+	Secret string
+
+	// The device ID returned by RegisterDevice
+	DeviceID string
+
+	// Optional Fields
+
+	// Client used to connect to the WebSocket server and
+	// download the messages. If nil, a Client with no settings
+	// is used.
+	Client *Client
+
+	// Time to wait before reconnecting after the connection
+	// is lost. It doubles after each failed attempt, up to
+	// MaxBackoff. Defaults to 1 second.
+	MinBackoff time.Duration
+
+	// Longest time to wait before reconnecting. Defaults to
+	// 5 minutes.
+	MaxBackoff time.Duration
+
+	// The connection is considered lost if nothing, not even
+	// a keep-alive, is received for this long. Pushover sends
+	// keep-alives about every 30 seconds. Defaults to 90
+	// seconds.
+	KeepAliveTimeout time.Duration
+
+	// Called with the errors that cause a reconnect, such as a
+	// lost connection or a failed message download. May be nil.
+	ErrorHandler func(err error)
+
+	// Highest message ID delivered, so messages that are not
+	// yet deleted are not delivered twice. It is not guarded,
+	// as only one Listen runs at a time.
+	highestID int64
+}
+
+// NewListener returns a Listener for the device with the
+// default options
+func NewListener(secret, deviceID string) *Listener {
+	return &Listener{
+		Secret:   secret,
+		DeviceID: deviceID,
+	}
+}
+
+// client returns the Listener's Client if set, otherwise the
+// Client used by the package functions
+func (l *Listener) client() *Client {
+	if l.Client == nil {
+		return defaultClient
+	}
+
+	return l.Client
+}
+
+// Listen connects to the Pushover Open Client WebSocket and
+// delivers the device's messages on the messages channel,
+// starting with any messages already waiting. It reconnects
+// with a backoff when the connection is lost and returns when
+// the context is done, or with ErrSessionFailed or
+// ErrAnotherSession when Pushover closes the session or rejects
+// the device.
+//
+//	messages := make(chan openclient.ReceivedMessage)
+//	l := openclient.NewListener(secret, deviceID)
+//	go func() {
+//	  err := l.Listen(ctx, messages)
+//	  ...
+//	}()
+//	for m := range messages {
+//	  ...
+//	}
+//
+// The messages channel is not closed by Listen. Listen must
+// not be called again on the same Listener until it returns.
+func (l *Listener) Listen(ctx context.Context, messages chan<- ReceivedMessage) error {
+	minBackoff, maxBackoff := l.MinBackoff, l.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultMinBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = defaultMaxBackoff
+		if maxBackoff < minBackoff {
+			maxBackoff = minBackoff
+		}
+	}
+
+	backoff := minBackoff
+
+	for {
+		connected, err := l.session(ctx, messages)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		switch err.(type) {
+		case *ErrSessionFailed, *ErrAnotherSession:
+			return err
+		}
+
+		// A download rejected for the secret or device fails
+		// again after reconnecting
+		var apiErr *pushover.APIError
+		if errors.As(err, &apiErr) && !errors.Is(err, pushover.ErrServer) &&
+			!errors.Is(err, pushover.ErrOverQuota) {
+			return &ErrSessionFailed{Err: err}
+		}
+
+		if connected {
+			backoff = minBackoff
+		}
+
+		// Reconnect after a reload without growing the backoff,
+		// but never without waiting
+		reload := errors.Is(err, errReload)
+		wait := backoff
+		if reload {
+			wait = minBackoff
+		} else if l.ErrorHandler != nil {
+			l.ErrorHandler(err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if reload {
+			continue
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// session logs in to the WebSocket server and handles frames
+// until the connection ends. It reports whether any frame was
+// received so the caller can reset the backoff.
+func (l *Listener) session(ctx context.Context, messages chan<- ReceivedMessage) (bool, error) {
+	ws, err := dialWebSocket(ctx, l.client().webSocketURL())
+	if err != nil {
+		return false, err
+	}
+	defer ws.Close()
+
+	// Unblock the read when the context is done
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-stop:
+		}
+	}()
+
+	if _, err := ws.Write([]byte("login:" + l.DeviceID + ":" + l.Secret + "\n")); err != nil {
+		return false, err
+	}
+
+	// Download the messages that arrived while disconnected
+	if err := l.deliver(ctx, messages); err != nil {
+		return false, err
+	}
+
+	keepAliveTimeout := l.KeepAliveTimeout
+	if keepAliveTimeout <= 0 {
+		keepAliveTimeout = defaultKeepAliveTimeout
+	}
+
+	received := false
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(keepAliveTimeout)); err != nil {
+			return received, err
+		}
+
+		var frame []byte
+		if err := websocket.Message.Receive(ws, &frame); err != nil {
+			return received, err
+		}
+		received = true
+
+		for _, b := range frame {
+			switch b {
+			case frameKeepAlive:
+			case frameNewMessage:
+				if err := l.deliver(ctx, messages); err != nil {
+					return received, err
+				}
+			case frameReload:
+				return received, errReload
+			case frameError:
+				return received, &ErrSessionFailed{}
+			case frameAnotherSession:
+				return received, &ErrAnotherSession{}
+			}
+		}
+	}
+}
+
+// deliver downloads the device's messages and sends the ones
+// not yet delivered on the messages channel
+func (l *Listener) deliver(ctx context.Context, messages chan<- ReceivedMessage) error {
+	r, err := l.client().DownloadMessages(ctx, l.Secret, l.DeviceID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range r.Messages {
+		if m.ID <= l.highestID {
+			continue
+		}
+
+		select {
+		case messages <- m:
+			l.highestID = m.ID
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// dialWebSocket connects to a WebSocket server, honouring the
+// context while connecting
+func dialWebSocket(ctx context.Context, wsURL string) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(wsURL, "https://pushover.net/")
+	if err != nil {
+		return nil, err
+	}
+
+	host := config.Location.Host
+	if len(config.Location.Port()) == 0 {
+		if config.Location.Scheme == "wss" {
+			host += ":443"
+		} else {
+			host += ":80"
+		}
+	}
+
+	var conn net.Conn
+	dialer := &net.Dialer{}
+	if config.Location.Scheme == "wss" {
+		tlsDialer := &tls.Dialer{
+			NetDialer: dialer,
+			Config:    &tls.Config{ServerName: config.Location.Hostname(), MinVersion: tls.VersionTLS12},
+		}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", host)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the handshake by the context. A server that
+	// accepts the connection but never completes the handshake
+	// is abandoned by closing the connection when the context
+	// is done.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	ws, err := websocket.NewClient(config, conn)
+	close(stop)
+	<-stopped
+
+	if ctx.Err() != nil {
+		conn.Close()
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	return ws, nil
+}
@@ -0,0 +1,353 @@
+package openclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+	"golang.org/x/net/websocket"
+)
+
+// pushServer is a stand-in for the Pushover Open Client
+// WebSocket and messages servers. Each connection to the
+// WebSocket follows the next script, a list of steps:
+//
+//	"#", "!", "R", "E", "A"  send the frame
+//	"+N"                     add message N to the device
+//	"close"                  close the connection
+//	"wait"                   wait for the client to close
+type pushServer struct {
+	mu       sync.Mutex
+	scripts  [][]string
+	logins   []string
+	messages []int
+
+	// HTTP status of the next failed download, if not zero
+	failStatus int
+}
+
+func (s *pushServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/push", websocket.Handler(func(ws *websocket.Conn) {
+		buf := make([]byte, 128)
+		n, err := ws.Read(buf)
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.logins = append(s.logins, string(buf[:n]))
+		var script []string
+		if len(s.scripts) > 0 {
+			script, s.scripts = s.scripts[0], s.scripts[1:]
+		} else {
+			script = []string{"A"}
+		}
+		s.mu.Unlock()
+
+		for _, step := range script {
+			switch {
+			case step == "close":
+				return
+			case step == "wait":
+				_, _ = ws.Read(buf)
+				return
+			case strings.HasPrefix(step, "+"):
+				var id int
+				fmt.Sscanf(step, "+%d", &id)
+				s.mu.Lock()
+				s.messages = append(s.messages, id)
+				s.mu.Unlock()
+			default:
+				_ = websocket.Message.Send(ws, []byte(step))
+			}
+		}
+
+		// Leave the connection open for the client to close
+		_, _ = ws.Read(buf)
+	}))
+
+	mux.HandleFunc("/messages.json", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.failStatus != 0 {
+			w.WriteHeader(s.failStatus)
+			s.failStatus = 0
+			fmt.Fprintf(w, `{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"%s"}`, id)
+			return
+		}
+
+		var messages []string
+		for _, v := range s.messages {
+			messages = append(messages, fmt.Sprintf(`{"id":%d,"umid":%d,"message":"message %d","app":"Test","aid":1,"date":1360019238,"priority":0,"acked":0}`, v, v+1000, v))
+		}
+
+		fmt.Fprintf(w, `{"messages":[%s],"status":1,"request":"%s"}`, strings.Join(messages, ","), id)
+	})
+
+	return mux
+}
+
+func startPushServer(t *testing.T, messages []int, scripts ...[]string) (*pushServer, *httptest.Server) {
+	s := &pushServer{scripts: scripts, messages: messages}
+	server := httptest.NewServer(s.handler())
+	t.Cleanup(server.Close)
+
+	return s, server
+}
+
+// testClient returns a Client that connects to the stand-in
+// server
+func testClient(server *httptest.Server) *Client {
+	return &Client{
+		Pushover:     &pushover.Client{BaseURL: server.URL},
+		WebSocketURL: "ws" + strings.TrimPrefix(server.URL, "http") + "/push",
+	}
+}
+
+func listen(ctx context.Context, l *Listener) ([]int64, error) {
+	messages := make(chan ReceivedMessage, 100)
+	err := l.Listen(ctx, messages)
+	close(messages)
+
+	var ids []int64
+	for m := range messages {
+		ids = append(ids, m.ID)
+	}
+
+	return ids, err
+}
+
+func testListener(server *httptest.Server) (*Listener, *[]error) {
+	var errs []error
+
+	l := NewListener("secret", "device")
+	l.Client = testClient(server)
+	l.MinBackoff = time.Millisecond
+	l.MaxBackoff = 4 * time.Millisecond
+	l.ErrorHandler = func(err error) { errs = append(errs, err) }
+
+	return l, &errs
+}
+
+func TestListenerFrames(t *testing.T) {
+	s, server := startPushServer(t, []int{1},
+		[]string{"#", "+2", "!", "#", "!", "R"},
+		[]string{"+3", "!", "A"},
+	)
+
+	l, errs := testListener(server)
+	ids, err := listen(context.TODO(), l)
+
+	if _, ok := err.(*ErrAnotherSession); !ok || len(err.Error()) == 0 {
+		t.Error("Another session", err)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Error("Messages delivered", ids)
+	}
+
+	if len(s.logins) != 2 || s.logins[0] != "login:device:secret\n" {
+		t.Error("Login", s.logins)
+	}
+
+	if len(*errs) != 0 {
+		t.Error("Reload reported as error", *errs)
+	}
+}
+
+func TestListenerSessionFailed(t *testing.T) {
+	_, server := startPushServer(t, nil, []string{"#", "E"})
+
+	l, _ := testListener(server)
+	ids, err := listen(context.TODO(), l)
+
+	if _, ok := err.(*ErrSessionFailed); !ok || len(err.Error()) == 0 || len(ids) != 0 {
+		t.Error("Session failed", err)
+	}
+}
+
+func TestListenerDownloadRejected(t *testing.T) {
+	s, server := startPushServer(t, []int{1}, []string{"wait"})
+
+	l, errs := testListener(server)
+	s.failStatus = http.StatusBadRequest
+
+	ids, err := listen(context.TODO(), l)
+
+	var apiErr *pushover.APIError
+	if _, ok := err.(*ErrSessionFailed); !ok || !errors.As(err, &apiErr) || len(ids) != 0 {
+		t.Error("Download rejected", err)
+	}
+
+	if len(*errs) != 0 || len(s.logins) != 1 {
+		t.Error("Reconnected after rejected download", *errs, len(s.logins))
+	}
+}
+
+func TestListenerReloadBackoff(t *testing.T) {
+	scripts := make([][]string, 100)
+	for i := range scripts {
+		scripts[i] = []string{"R"}
+	}
+	s, server := startPushServer(t, nil, scripts...)
+
+	l, _ := testListener(server)
+	l.MinBackoff = 20 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err := listen(ctx, l)
+	cancel()
+
+	s.mu.Lock()
+	logins := len(s.logins)
+	s.mu.Unlock()
+
+	if err != context.DeadlineExceeded || logins > 4 {
+		t.Error("Reload without backoff", err, logins)
+	}
+}
+
+func TestListenerReconnect(t *testing.T) {
+	s, server := startPushServer(t, []int{1},
+		[]string{"wait"},
+		[]string{"close"},
+		[]string{"wait"},
+		[]string{"+2", "!", "A"},
+	)
+
+	l, errs := testListener(server)
+	l.KeepAliveTimeout = 20 * time.Millisecond
+
+	// Fail the first download
+	s.failStatus = http.StatusInternalServerError
+
+	ids, err := listen(context.TODO(), l)
+	if _, ok := err.(*ErrAnotherSession); !ok {
+		t.Error("Another session", err)
+	}
+
+	if fmt.Sprint(ids) != "[1 2]" {
+		t.Error("Messages delivered", ids)
+	}
+
+	// Failed download, closed connection and keep-alive timeout
+	if len(*errs) != 3 || len(s.logins) != 4 {
+		t.Error("Reconnects", *errs, len(s.logins))
+	}
+}
+
+func TestListenerContext(t *testing.T) {
+	_, server := startPushServer(t, []int{1}, []string{"wait"})
+
+	// Cancelled while connected
+	l, _ := testListener(server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	ids, err := listen(ctx, l)
+	cancel()
+	if err != context.DeadlineExceeded || fmt.Sprint(ids) != "[1]" {
+		t.Error("Cancelled while connected", err, ids)
+	}
+
+	// Cancelled while delivering
+	_, server = startPushServer(t, []int{1, 2}, []string{"wait"})
+	l, _ = testListener(server)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	err = l.Listen(ctx, make(chan ReceivedMessage))
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Error("Cancelled while delivering", err)
+	}
+
+	// Cancelled while reconnecting
+	_, server = startPushServer(t, nil)
+	server.Close()
+	l, errs := testListener(server)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	_, err = listen(ctx, l)
+	cancel()
+	if err != context.DeadlineExceeded || len(*errs) == 0 {
+		t.Error("Cancelled while reconnecting", err)
+	}
+
+	// Default options
+	l = NewListener("secret", "device")
+	l.Client = testClient(server)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	_, err = listen(ctx, l)
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Error("Default options", err)
+	}
+}
+
+func TestDialWebSocket(t *testing.T) {
+	if _, err := dialWebSocket(context.TODO(), "://invalid"); err == nil {
+		t.Error("Invalid URL")
+	}
+
+	// The default ports are used when none is given. Nothing
+	// is expected to be listening on them locally.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for _, wsURL := range []string{"ws://127.0.0.1/push", "wss://127.0.0.1/push"} {
+		if _, err := dialWebSocket(ctx, wsURL); err == nil {
+			t.Error("Default port", wsURL)
+		}
+	}
+
+	// Not a WebSocket server
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	if _, err := dialWebSocket(context.TODO(), "ws"+strings.TrimPrefix(server.URL, "http")); err == nil {
+		t.Error("Not a WebSocket server")
+	}
+}
+
+func TestListenerHandshakeStalled(t *testing.T) {
+	// Accept connections but never complete the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	l := NewListener("secret", "device")
+	l.Client = &Client{WebSocketURL: "ws://" + listener.Addr().String() + "/push"}
+
+	// Cancelled without a deadline while connecting
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() { done <- l.Listen(ctx, make(chan ReceivedMessage)) }()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Error("Cancelled during handshake", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Handshake not cancelled")
+	}
+}
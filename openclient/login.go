@@ -0,0 +1,138 @@
+package openclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// LoginResponse is the response from the Pushover Open Client
+// login API. It is read from the body of the Pushover REST API
+// response and translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type LoginResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// The user's secret, used in place of the email address
+	// and password for the other Open Client API requests.
+	// It should be stored securely.
+	Secret string
+
+	// The user's key
+	ID string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// Login will submit a POST request to the Pushover Open Client
+// API. This function will log in to the user's account with
+// their email address and password and retrieve the user's
+// secret and key.
+//
+// If the user has two-factor authentication enabled, twofa must
+// be the user's current two-factor code. When it is missing or
+// wrong, the response is returned with ErrTwoFactorRequired so
+// the user can be prompted for the code and the login repeated.
+//
+//	resp, err := openclient.Login(context.Background(),
+//	  email, password, "")
+//	if _, ok := err.(*openclient.ErrTwoFactorRequired); ok {
+//	  resp, err = openclient.Login(context.Background(),
+//	    email, password, code)
+//	}
+func Login(ctx context.Context, email, password, twofa string) (*LoginResponse, error) {
+	return defaultClient.Login(ctx, email, password, twofa)
+}
+
+// Login will submit a POST request to the Pushover Open Client
+// API with the client's settings. This function will log in to
+// the user's account and retrieve the user's secret and key.
+//
+//	resp, err := client.Login(context.Background(),
+//	  email, password, "")
+func (c *Client) Login(ctx context.Context, email, password, twofa string) (*LoginResponse, error) {
+	formData := url.Values{
+		keyEmail:    {email},
+		keyPassword: {password},
+	}
+
+	if len(twofa) > 0 {
+		formData.Set(keyTwoFA, twofa)
+	}
+
+	resp, err := c.transport().PostForm(ctx, c.endpoint("/users/login.json"), formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		// The status alone asks for the two-factor code
+		if resp.StatusCode == http.StatusPreconditionFailed {
+			return &LoginResponse{
+				HTTPStatus:      resp.Status,
+				HTTPStatusCode:  resp.StatusCode,
+				Errors:          []string{},
+				ErrorParameters: make(map[string]string),
+			}, &ErrTwoFactorRequired{}
+		}
+
+		return nil, err
+	}
+
+	r := &LoginResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	var ok bool
+
+	// Populate secret and user key
+	if r.Secret, ok = a.Result[keySecret].(string); ok {
+		delete(a.Result, keySecret)
+	}
+
+	if r.ID, ok = a.Result[keyID].(string); ok {
+		delete(a.Result, keyID)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	if r.HTTPStatusCode == http.StatusPreconditionFailed {
+		return r, &ErrTwoFactorRequired{}
+	}
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
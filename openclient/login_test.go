@@ -0,0 +1,129 @@
+package openclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+)
+
+const id = "deadbeef-dead-beef-dead-deadbeefdead"
+
+/*
+Login Valid
+{"status":1,"id":"uQiRzpo4DXghDmr9QzzfQu27cmVRsG","secret":"dx6pfFbtcgwbrahCnd8wdakHFEqjpG","request":"6c5b9f3e-2a1d-4d4b-8a8f-0e1c2b3d4f5a"}
+
+Login Invalid
+{"errors":["invalid email and/or password"],"status":0,"request":"d1a0e9b8-c7f6-4e5d-9c4b-3a2f1e0d9c8b"}
+
+Login Two-Factor Required (HTTP 412)
+{"errors":["two-factor authentication code required"],"status":0,"request":"5e4d3c2b-1a0f-4e9d-8c7b-6a5f4e3d2c1b"}
+*/
+
+func loginServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	email := r.Form.Get("email")
+	password := r.Form.Get("password")
+
+	switch {
+	case email == "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"`, id)
+	case email == "failbody":
+		w.Header().Set("Content-Length", "1")
+	case email == "twofabody":
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, "Precondition Failed")
+	case password != "password":
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"errors":["invalid email and/or password"],"status":0,"request":"%s"}`, id)
+	case email == "twofa@example.com" && r.Form.Get("twofa") != "123456":
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprintf(w, `{"errors":["two-factor authentication code required"],"status":0,"request":"%s"}`, id)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"id":"uQiRzpo4DXghDmr9QzzfQu27cmVRsG","secret":"dx6pfFbtcgwbrahCnd8wdakHFEqjpG","request":"%s"}`, id)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(loginServerHandler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: &pushover.Client{BaseURL: apiServer.URL}}
+
+	// Valid login
+	r, e := client.Login(context.TODO(), "user@example.com", "password", "")
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.ID != "uQiRzpo4DXghDmr9QzzfQu27cmVRsG" || r.Secret != "dx6pfFbtcgwbrahCnd8wdakHFEqjpG" ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid login")
+	}
+
+	// Invalid password
+	r, e = client.Login(context.TODO(), "user@example.com", "wrong", "")
+	if !errors.As(e, new(*pushover.APIError)) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		len(r.Secret) > 0 || r.Errors[0] != "invalid email and/or password" {
+		t.Error("Invalid password")
+	}
+
+	// Two-factor code required
+	r, e = client.Login(context.TODO(), "twofa@example.com", "password", "")
+	if _, ok := e.(*ErrTwoFactorRequired); !ok || len(e.Error()) == 0 ||
+		r.HTTPStatusCode != http.StatusPreconditionFailed || r.Errors[0] != "two-factor authentication code required" {
+		t.Error("Two-factor code required")
+	}
+
+	// Two-factor code wrong
+	_, e = client.Login(context.TODO(), "twofa@example.com", "password", "654321")
+	if _, ok := e.(*ErrTwoFactorRequired); !ok {
+		t.Error("Two-factor code wrong")
+	}
+
+	// Two-factor code required without a status in the body
+	r, e = client.Login(context.TODO(), "twofabody", "password", "")
+	if _, ok := e.(*ErrTwoFactorRequired); !ok || r.HTTPStatusCode != http.StatusPreconditionFailed ||
+		len(r.Errors) != 0 {
+		t.Error("Two-factor code required without status")
+	}
+
+	// Two-factor code supplied
+	r, e = client.Login(context.TODO(), "twofa@example.com", "password", "123456")
+	if e != nil || r.APIStatus != 1 || r.Secret != "dx6pfFbtcgwbrahCnd8wdakHFEqjpG" {
+		t.Error("Two-factor code supplied")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = client.Login(ctx, "user@example.com", "password", "")
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	_, e = client.Login(context.TODO(), "failjson", "password", "")
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Invalid body
+	_, e = client.Login(context.TODO(), "failbody", "password", "")
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = client.Login(context.TODO(), "user@example.com", "password", "")
+	if e == nil {
+		t.Error("No API server")
+	}
+}
@@ -0,0 +1,297 @@
+package openclient
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+// ReceivedMessage is a message downloaded from the Pushover
+// Open Client API. See the Pushover Open Client API
+// documentation for more information on these fields.
+type ReceivedMessage struct {
+	// ID of the message on the device, used to delete
+	// messages with DeleteMessages
+	ID int64 `json:"id"`
+
+	// Message title
+	Title string `json:"title"`
+
+	// Message body
+	Message string `json:"message"`
+
+	// Name of the application that sent the message
+	App string `json:"app"`
+
+	// ID of the application that sent the message
+	AID int64 `json:"aid"`
+
+	// Name of the application's icon. The icon is available
+	// at https://api.pushover.net/icons/<icon>.png
+	Icon string `json:"icon"`
+
+	// Time the message was sent
+	Date time.Time `json:"date"`
+
+	// Message priority, from -2 to 2
+	Priority int `json:"priority"`
+
+	// True if the emergency priority message has been
+	// acknowledged
+	Acked bool `json:"acked"`
+
+	// Unique ID of the message across all the user's devices
+	UMID int64 `json:"umid"`
+
+	// Supplementary URL
+	URL string `json:"url"`
+
+	// Title for the supplementary URL
+	URLTitle string `json:"url_title"`
+
+	// True if the message contains HTML
+	HTML bool `json:"html"`
+
+	// Receipt of an emergency priority message, used to
+	// acknowledge it with AcknowledgeMessage
+	Receipt string `json:"receipt,omitempty"`
+}
+
+// DownloadMessagesResponse is the response from the Pushover
+// Open Client messages API. It is read from the body of the
+// Pushover REST API response and translated to this response
+// structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type DownloadMessagesResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Messages waiting on the device, oldest first
+	Messages []ReceivedMessage
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// HighestID returns the highest message ID in the response,
+// or 0 if there are no messages
+func (r *DownloadMessagesResponse) HighestID() int64 {
+	var highest int64
+
+	for _, m := range r.Messages {
+		if m.ID > highest {
+			highest = m.ID
+		}
+	}
+
+	return highest
+}
+
+// DeleteMessagesResponse is the response from the Pushover
+// Open Client API that deletes messages. It is read from the
+// body of the Pushover REST API response and translated to this
+// response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type DeleteMessagesResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// DownloadMessages will submit a GET request to the Pushover
+// Open Client API. This function will download the messages
+// waiting on the device registered with RegisterDevice.
+//
+// Messages remain on the device until they are deleted with
+// DeleteMessages.
+//
+//	resp, err := openclient.DownloadMessages(context.Background(),
+//	  secret, deviceID)
+func DownloadMessages(ctx context.Context, secret, deviceID string) (*DownloadMessagesResponse, error) {
+	return defaultClient.DownloadMessages(ctx, secret, deviceID)
+}
+
+// DownloadMessages will submit a GET request to the Pushover
+// Open Client API with the client's settings. This function
+// will download the messages waiting on the device.
+//
+//	resp, err := client.DownloadMessages(context.Background(),
+//	  secret, deviceID)
+func (c *Client) DownloadMessages(ctx context.Context, secret, deviceID string) (*DownloadMessagesResponse, error) {
+	query := url.Values{
+		keySecret:   {secret},
+		keyDeviceID: {deviceID},
+	}
+
+	resp, err := c.transport().GetURL(ctx, c.endpoint("/messages.json")+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DownloadMessagesResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+		Messages:       []ReceivedMessage{},
+	}
+
+	// Populate messages
+	if messages, ok := a.Result[keyMessages].([]interface{}); ok {
+		r.Messages = interfaceArrayToMessages(messages)
+		delete(a.Result, keyMessages)
+	}
+
+	// The user and device details are not translated
+	delete(a.Result, keyUser)
+	delete(a.Result, keyDevice)
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// DeleteMessages will submit a POST request to the Pushover
+// Open Client API. This function will delete the messages on
+// the device with an ID up to and including highestID,
+// usually the HighestID of a DownloadMessagesResponse.
+//
+//	resp, err := openclient.DeleteMessages(context.Background(),
+//	  secret, deviceID, messages.HighestID())
+func DeleteMessages(ctx context.Context, secret, deviceID string, highestID int64) (*DeleteMessagesResponse, error) {
+	return defaultClient.DeleteMessages(ctx, secret, deviceID, highestID)
+}
+
+// DeleteMessages will submit a POST request to the Pushover
+// Open Client API with the client's settings. This function
+// will delete the messages on the device with an ID up to and
+// including highestID.
+//
+//	resp, err := client.DeleteMessages(context.Background(),
+//	  secret, deviceID, messages.HighestID())
+func (c *Client) DeleteMessages(ctx context.Context, secret, deviceID string, highestID int64) (*DeleteMessagesResponse, error) {
+	formData := url.Values{
+		keySecret:  {secret},
+		keyMessage: {strconv.FormatInt(highestID, 10)},
+	}
+
+	resp, err := c.transport().PostForm(ctx, c.endpoint("/devices/"+url.PathEscape(deviceID)+"/update_highest_message.json"), formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &DeleteMessagesResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+func interfaceArrayToMessages(values []interface{}) []ReceivedMessage {
+	messages := make([]ReceivedMessage, 0, len(values))
+
+	for _, v := range values {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var message ReceivedMessage
+		message.ID = mapKeyToInt64(keyID, m)
+		message.Title, _ = m[keyTitle].(string)
+		message.Message, _ = m[keyMessage].(string)
+		message.App, _ = m[keyApp].(string)
+		message.AID = mapKeyToInt64(keyAID, m)
+		message.Icon, _ = m[keyIcon].(string)
+		if date := mapKeyToInt64(keyDate, m); date != 0 {
+			message.Date = time.Unix(date, 0)
+		}
+		message.Priority, _ = api.MapKeyToInt(keyPriority, m)
+		message.Acked = mapKeyToInt64(keyAcked, m) != 0
+		message.UMID = mapKeyToInt64(keyUMID, m)
+		message.URL, _ = m[keyURL].(string)
+		message.URLTitle, _ = m[keyURLTitle].(string)
+		message.HTML = mapKeyToInt64(keyHTML, m) != 0
+		message.Receipt, _ = m[keyReceipt].(string)
+
+		messages = append(messages, message)
+	}
+
+	return messages
+}
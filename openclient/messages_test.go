@@ -0,0 +1,168 @@
+package openclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+)
+
+/*
+Messages Valid
+{"messages":[{"id":3,"id_str":"3","umid":1234,"umid_str":"1234","title":"Backup","message":"Backup <b>complete</b>","app":"Backups","aid":42,"aid_str":"42","icon":"backups","date":1360019238,"queued_date":1360019239,"dispatched_date":1360019240,"priority":1,"acked":0,"url":"https://example.com","url_title":"Details","html":1}],"user":{"quiet_hours":false,"is_android_licensed":false,"is_ios_licensed":false,"is_desktop_licensed":true},"device":{"name":"workstation"},"status":1,"request":"4b8f3c2a-1d9e-4f7b-8a6c-5e4d3c2b1a09"}
+
+Messages Invalid Secret
+{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+
+Update Highest Message Valid
+{"status":1,"request":"6d5c4b3a-2f1e-4d0c-9b8a-7f6e5d4c3b2a"}
+*/
+
+func messagesServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Form.Get("secret") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"secret":"invalid","errors":["secret is invalid; please log in again"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/messages.json"):
+		switch r.Form.Get("device_id") {
+		case "failjson":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"messages":[],"status":1,"request":"%s"`, id)
+		case "empty":
+			fmt.Fprintf(w, `{"messages":[],"user":{},"device":{},"status":1,"request":"%s"}`, id)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"messages":[{"id":3,"id_str":"3","umid":1234,"title":"Backup","message":"Backup <b>complete</b>","app":"Backups","aid":42,"icon":"backups","date":1360019238,"priority":1,"acked":0,"url":"https://example.com","url_title":"Details","html":1},{"id":5,"umid":1235,"message":"Disk full","app":"Monitor","aid":43,"icon":"monitor","date":1360019300,"priority":2,"acked":1,"receipt":"rcpt"},"invalid"],"user":{"is_desktop_licensed":true},"device":{"name":"workstation"},"status":1,"request":"%s"}`, id)
+		}
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/update_highest_message.json"):
+		device := strings.TrimSuffix(r.URL.Path, "/update_highest_message.json")
+		device = device[strings.LastIndex(device, "/")+1:]
+
+		switch {
+		case device == "failjson":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"status":1,"request":"%s"`, id)
+		case device != "device" || r.Form.Get("message") != "5":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"message":"invalid","errors":["message is invalid"],"status":0,"request":"%s"}`, id)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestDownloadMessages(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(messagesServerHandler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: &pushover.Client{BaseURL: apiServer.URL}}
+
+	// Valid download
+	r, e := client.DownloadMessages(context.TODO(), "secret", "device")
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Messages) != 2 || r.HighestID() != 5 || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Fatal("Valid download")
+	}
+
+	m := r.Messages[0]
+	if m.ID != 3 || m.UMID != 1234 || m.Title != "Backup" || m.Message != "Backup <b>complete</b>" ||
+		m.App != "Backups" || m.AID != 42 || m.Icon != "backups" || m.Date.Unix() != 1360019238 ||
+		m.Priority != 1 || m.Acked || m.URL != "https://example.com" || m.URLTitle != "Details" || !m.HTML || m.Receipt != "" {
+		t.Error("Message fields")
+	}
+
+	m = r.Messages[1]
+	if m.ID != 5 || m.Title != "" || m.Priority != 2 || !m.Acked || m.HTML || m.URL != "" || m.Receipt != "rcpt" {
+		t.Error("Message optional fields")
+	}
+
+	// No messages
+	r, e = client.DownloadMessages(context.TODO(), "secret", "empty")
+	if e != nil || r.APIStatus != 1 || len(r.Messages) != 0 || r.HighestID() != 0 || len(r.ErrorParameters) > 0 {
+		t.Error("No messages")
+	}
+
+	// Invalid secret
+	r, e = client.DownloadMessages(context.TODO(), "invalid", "device")
+	if !errors.As(e, new(*pushover.APIError)) || r.APIStatus != 0 || len(r.Messages) != 0 || r.ErrorParameters["secret"] != "invalid" {
+		t.Error("Invalid secret")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = client.DownloadMessages(ctx, "secret", "device")
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	_, e = client.DownloadMessages(context.TODO(), "secret", "failjson")
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = client.DownloadMessages(context.TODO(), "secret", "device")
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestDeleteMessages(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(messagesServerHandler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: &pushover.Client{BaseURL: apiServer.URL}}
+
+	// Valid delete
+	r, e := client.DeleteMessages(context.TODO(), "secret", "device", 5)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid delete")
+	}
+
+	// Invalid message
+	r, e = client.DeleteMessages(context.TODO(), "secret", "device", 4)
+	if !errors.As(e, new(*pushover.APIError)) || r.APIStatus != 0 || r.ErrorParameters["message"] != "invalid" {
+		t.Error("Invalid message")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = client.DeleteMessages(ctx, "secret", "device", 5)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	_, e = client.DeleteMessages(context.TODO(), "secret", "failjson", 5)
+	if _, ok := e.(*pushover.ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = client.DeleteMessages(context.TODO(), "secret", "device", 5)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
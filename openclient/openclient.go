@@ -0,0 +1,181 @@
+// Package openclient implements access to the Pushover Open
+// Client API, used to receive messages on a desktop device
+//
+// This documentation can be considered a supplement to the
+// official Pushover Open Client API documentation at
+// https://pushover.net/api/client. Refer to that official
+// documentation for the details on how to use these
+// library functions.
+//
+// As in the pushover package, a request rejected by the
+// Pushover API returns the response with a *pushover.APIError.
+package openclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arcanericky/pushover"
+	"github.com/arcanericky/pushover/internal/api"
+)
+
+const (
+	keyAcked    = "acked"
+	keyAID      = "aid"
+	keyApp      = "app"
+	keyDate     = "date"
+	keyDevice   = "device"
+	keyDeviceID = "device_id"
+	keyEmail    = "email"
+	keyHTML     = "html"
+	keyIcon     = "icon"
+	keyID       = "id"
+	keyMessage  = "message"
+	keyMessages = "messages"
+	keyName     = "name"
+	keyOS       = "os"
+	keyPassword = "password"
+	keyPriority = "priority"
+	keyReceipt  = "receipt"
+	keySecret   = "secret"
+	keyTitle    = "title"
+	keyTwoFA    = "twofa"
+	keyUMID     = "umid"
+	keyURL      = "url"
+	keyURLTitle = "url_title"
+	keyUser     = "user"
+)
+
+// ErrInvalidDeviceName indicates a device name does not follow
+// the Pushover rules for device names
+type ErrInvalidDeviceName struct {
+	// The rejected device name
+	Name string
+
+	// The rule the device name breaks
+	Reason string
+}
+
+func (dn *ErrInvalidDeviceName) Error() string {
+	return fmt.Sprintf("Invalid device name %q: %s", dn.Name, dn.Reason)
+}
+
+// ErrTwoFactorRequired indicates the user has two-factor
+// authentication enabled and the request must be repeated
+// with the user's current two-factor code
+type ErrTwoFactorRequired struct{}
+
+func (tf *ErrTwoFactorRequired) Error() string {
+	return "Two-factor authentication code required"
+}
+
+const (
+	defaultBaseURL      = "https://api.pushover.net/1"
+	defaultWebSocketURL = "wss://client.pushover.net/push"
+)
+
+// Client holds the settings shared by requests to the Pushover
+// Open Client API. Its methods submit the same requests as the
+// package functions of the same name. The package functions use
+// a Client with no settings.
+//
+// A Client is safe for use by multiple goroutines. Its fields
+// must not be changed while it is in use.
+type Client struct {
+	// Settings shared with the pushover package: the HTTP
+	// client, base URL, user agent and timeout. The Token is
+	// not used by the Open Client API.
+	//
+	// If nil, a pushover.Client with no settings is used.
+	Pushover *pushover.Client
+
+	// URL of the Pushover Open Client WebSocket server used by
+	// Listener, such as wss://client.pushover.net/push
+	//
+	// Leave this empty unless you wish to override the URL.
+	WebSocketURL string
+}
+
+// defaultClient is the Client used by the package functions
+var defaultClient = &Client{}
+
+// transport returns the HTTP client, user agent and timeout of
+// the client's pushover.Client for submitting requests
+func (c *Client) transport() *api.Transport {
+	if c.Pushover == nil {
+		return &api.Transport{}
+	}
+
+	return &api.Transport{
+		HTTPClient: c.Pushover.HTTPClient,
+		UserAgent:  c.Pushover.UserAgent,
+		Timeout:    c.Pushover.Timeout,
+	}
+}
+
+// endpoint returns the path appended to the BaseURL of the
+// client's pushover.Client if set, otherwise to the Pushover
+// REST API URL
+func (c *Client) endpoint(path string) string {
+	if c.Pushover != nil && len(c.Pushover.BaseURL) > 0 {
+		return strings.TrimSuffix(c.Pushover.BaseURL, "/") + path
+	}
+
+	return defaultBaseURL + path
+}
+
+// webSocketURL returns the client's WebSocketURL if set,
+// otherwise the Pushover Open Client WebSocket server URL
+func (c *Client) webSocketURL() string {
+	if len(c.WebSocketURL) == 0 {
+		return defaultWebSocketURL
+	}
+
+	return c.WebSocketURL
+}
+
+// apiResponse is a Pushover API response with the fields
+// common to every response decoded
+type apiResponse struct {
+	*api.Response
+}
+
+// readResponse reads the body of a Pushover API response and
+// decodes the fields present in every response. A body that
+// cannot be read or decoded is reported with
+// pushover.ErrInvalidResponse.
+func readResponse(resp *http.Response) (*apiResponse, error) {
+	r, err := api.ReadResponse(resp)
+	if err != nil {
+		return nil, &pushover.ErrInvalidResponse{HTTPStatusCode: resp.StatusCode, Err: err}
+	}
+
+	return &apiResponse{Response: r}, nil
+}
+
+// apiError returns a pushover.APIError with the errors of the
+// response if the Pushover API rejected the request, otherwise
+// nil
+func (r *apiResponse) apiError(errors []string, errorParameters map[string]string) error {
+	if r.APIStatus == 1 {
+		return nil
+	}
+
+	return &pushover.APIError{
+		HTTPStatus:      r.HTTPStatus,
+		HTTPStatusCode:  r.HTTPStatusCode,
+		APIStatus:       r.APIStatus,
+		Request:         r.Request,
+		Errors:          errors,
+		ErrorParameters: errorParameters,
+	}
+}
+
+// mapKeyToInt64 converts a number to an int64. Missing keys
+// and other types are translated to 0.
+func mapKeyToInt64(key string, m map[string]interface{}) int64 {
+	value, _ := m[key].(float64)
+
+	return int64(value)
+}
@@ -0,0 +1,106 @@
+package openclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arcanericky/pushover"
+)
+
+type clientServer struct {
+	mu         sync.Mutex
+	userAgents map[string]string
+}
+
+func (s *clientServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.userAgents[r.URL.Path] = r.UserAgent()
+	s.mu.Unlock()
+
+	if r.URL.Query().Get("secret") == "slow" {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	fmt.Fprintf(w, `{"id":"id","secret":"secret","messages":[],"status":1,"request":"%s"}`, id)
+}
+
+func TestClient(t *testing.T) {
+	server := &clientServer{userAgents: make(map[string]string)}
+	apiServer := httptest.NewServer(http.HandlerFunc(server.handler))
+	defer apiServer.Close()
+
+	client := &Client{Pushover: pushover.NewClient("")}
+	client.Pushover.BaseURL = apiServer.URL + "/1/"
+	client.Pushover.UserAgent = "pushover-test/1.0"
+
+	ctx := context.TODO()
+
+	calls := []struct {
+		path string
+		call func() error
+	}{
+		{"/1/users/login.json", func() error { _, e := client.Login(ctx, "user@example.com", "password", ""); return e }},
+		{"/1/devices.json", func() error { _, e := client.RegisterDevice(ctx, "secret", "device"); return e }},
+		{"/1/messages.json", func() error { _, e := client.DownloadMessages(ctx, "secret", "device"); return e }},
+		{"/1/devices/device/update_highest_message.json", func() error {
+			_, e := client.DeleteMessages(ctx, "secret", "device", 1)
+			return e
+		}},
+		{"/1/receipts/receipt/acknowledge.json", func() error {
+			_, e := client.AcknowledgeMessage(ctx, "secret", "receipt")
+			return e
+		}},
+	}
+
+	for _, c := range calls {
+		if e := c.call(); e != nil {
+			t.Errorf("%s: %v", c.path, e)
+			continue
+		}
+
+		server.mu.Lock()
+		userAgent, ok := server.userAgents[c.path]
+		server.mu.Unlock()
+		if !ok || userAgent != client.Pushover.UserAgent {
+			t.Errorf("%s: user agent %q", c.path, userAgent)
+		}
+	}
+
+	// Request exceeds the client timeout
+	client.Pushover.Timeout = 10 * time.Millisecond
+	if _, e := client.DownloadMessages(ctx, "slow", "device"); e != context.DeadlineExceeded {
+		t.Error("Client timeout")
+	}
+}
+
+func TestDefaultClient(t *testing.T) {
+	// The package functions use the default client. A cancelled
+	// context stops each request before it is sent.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func() error{
+		"Login":              func() error { _, e := Login(ctx, "user@example.com", "password", ""); return e },
+		"RegisterDevice":     func() error { _, e := RegisterDevice(ctx, "secret", "device"); return e },
+		"DownloadMessages":   func() error { _, e := DownloadMessages(ctx, "secret", "device"); return e },
+		"DeleteMessages":     func() error { _, e := DeleteMessages(ctx, "secret", "device", 1); return e },
+		"AcknowledgeMessage": func() error { _, e := AcknowledgeMessage(ctx, "secret", "receipt"); return e },
+		"Listen":             func() error { return NewListener("secret", "device").Listen(ctx, nil) },
+	}
+
+	for name, call := range calls {
+		if e := call(); e != context.Canceled {
+			t.Errorf("%s: %v", name, e)
+		}
+	}
+
+	if defaultClient.endpoint("/messages.json") != "https://api.pushover.net/1/messages.json" ||
+		defaultClient.webSocketURL() != "wss://client.pushover.net/push" {
+		t.Error("Default URLs")
+	}
+}
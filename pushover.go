@@ -8,32 +8,76 @@
 package pushover
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arcanericky/pushover/internal/api"
 )
 
 const (
-	keyCallback  = "callback"
-	keyDevice    = "device"
-	keyDevices   = "devices"
-	keyErrors    = "errors"
-	keyExpire    = "expire"
-	keyGroup     = "group"
-	keyHTML      = "html"
-	keyLicenses  = "licenses"
-	keyMessage   = "message"
-	keyMonospace = "monospace"
-	keyPriority  = "priority"
-	keyReceipt   = "receipt"
-	keyRequest   = "request"
-	keyRetry     = "retry"
-	keySound     = "sound"
-	keyStatus    = "status"
-	keyTimestamp = "timestamp"
-	keyTitle     = "title"
-	keyToken     = "token"
-	keyURL       = "url"
-	keyURLTitle  = "url_title"
-	keyUser      = "user"
+	keyAcknowledged         = "acknowledged"
+	keyAcknowledgedAt       = "acknowledged_at"
+	keyAcknowledgedBy       = "acknowledged_by"
+	keyAcknowledgedByDevice = "acknowledged_by_device"
+	keyAdmin                = "admin"
+	keyAttachment           = "attachment"
+	keyCallback             = "callback"
+	keyCalledBack           = "called_back"
+	keyCalledBackAt         = "called_back_at"
+	keyCanceled             = "canceled"
+	keyCount                = "count"
+	keyCredits              = "credits"
+	keyDevice               = "device"
+	keyDeviceName           = "device_name"
+	keyDevices              = "devices"
+	keyDisabled             = "disabled"
+	keyEmail                = "email"
+	keyExpire               = "expire"
+	keyExpired              = "expired"
+	keyExpiresAt            = "expires_at"
+	keyGroup                = "group"
+	keyGroups               = "groups"
+	keyHTML                 = "html"
+	keyInstant              = "instant"
+	keyLastDeliveredAt      = "last_delivered_at"
+	keyLicenses             = "licenses"
+	keyLimit                = "limit"
+	keyMemo                 = "memo"
+	keyMessage              = "message"
+	keyMonospace            = "monospace"
+	keyName                 = "name"
+	keyOS                   = "os"
+	keyPassword             = "password"
+	keyPercent              = "percent"
+	keyPriority             = "priority"
+	keyReceipt              = "receipt"
+	keyRemaining            = "remaining"
+	keyReset                = "reset"
+	keyRetry                = "retry"
+	keySound                = "sound"
+	keySounds               = "sounds"
+	keySubscribedUserKey    = "subscribed_user_key"
+	keySubscription         = "subscription"
+	keySubtext              = "subtext"
+	keyTags                 = "tags"
+	keyText                 = "text"
+	keyTimestamp            = "timestamp"
+	keyTitle                = "title"
+	keyToken                = "token"
+	keyURL                  = "url"
+	keyURLTitle             = "url_title"
+	keyUser                 = "user"
+	keyUsers                = "users"
+)
+
+const (
+	headerAppLimit     = "X-Limit-App-Limit"
+	headerAppRemaining = "X-Limit-App-Remaining"
+	headerAppReset     = "X-Limit-App-Reset"
 )
 
 // ErrInvalidRequest indicates invalid request data
@@ -46,22 +90,240 @@ func (ir *ErrInvalidRequest) Error() string {
 
 // ErrInvalidResponse indicates an invalid response body
 // was received from the Pushover API
-type ErrInvalidResponse struct{}
+type ErrInvalidResponse struct {
+	// HTTP Status Code of the response
+	//
+	// Zero if no response was received
+	HTTPStatusCode int
+
+	// The error reading or decoding the response body
+	Err error
+}
 
 func (ir *ErrInvalidResponse) Error() string {
-	return "Invalid response"
+	if ir.Err == nil {
+		return "Invalid response"
+	}
+
+	return "Invalid response: " + ir.Err.Error()
+}
+
+// Unwrap returns the error reading or decoding the response
+// body
+func (ir *ErrInvalidResponse) Unwrap() error {
+	return ir.Err
 }
 
-var messagesURL = "https://api.pushover.net/1/messages.json"
-var validateURL = "https://api.pushover.net/1/users/validate.json"
+// Is reports whether the response was a server error, so an
+// invalid response can be matched with ErrServer
+func (ir *ErrInvalidResponse) Is(target error) bool {
+	return target == ErrServer && ir.HTTPStatusCode >= http.StatusInternalServerError
+}
 
-func mapKeyToInt(key string, m map[string]interface{}) (int, bool) {
-	var value float64
-	var result int
-	var ok bool
+// ErrReceiptExpired indicates a notification sent with a
+// priority of 2 expired before it was acknowledged
+type ErrReceiptExpired struct{}
+
+func (re *ErrReceiptExpired) Error() string {
+	return "Receipt expired without acknowledgement"
+}
+
+// ErrUnknownSound indicates the sound in a message request
+// is not one of the sounds available to the application
+type ErrUnknownSound struct {
+	// The rejected sound name
+	Sound string
+}
+
+func (us *ErrUnknownSound) Error() string {
+	return "Unknown sound " + us.Sound
+}
+
+// ErrSoundsURLRequired indicates a message request enables
+// VerifySound with a PushoverURL but no SoundsURL, so the sound
+// cannot be verified without sending the token to the Pushover
+// Sounds API
+type ErrSoundsURLRequired struct{}
+
+func (su *ErrSoundsURLRequired) Error() string {
+	return "Sounds URL required to verify the sound"
+}
+
+// Errors matched by an APIError with errors.Is
+var (
+	// ErrInvalidToken indicates the application token was
+	// rejected
+	ErrInvalidToken = errors.New("invalid application token")
+
+	// ErrInvalidUser indicates the user or group key was
+	// rejected
+	ErrInvalidUser = errors.New("invalid user or group key")
+
+	// ErrOverQuota indicates the application has sent its
+	// monthly message limit
+	ErrOverQuota = errors.New("over message quota")
+
+	// ErrServer indicates the Pushover API failed to process
+	// the request. The request may be retried later.
+	ErrServer = errors.New("server error")
+)
+
+// APIError indicates the Pushover API rejected a request with
+// a status other than 1. It is returned together with the
+// response, which holds the same errors.
+//
+//	  resp, err := pushover.Message(request)
+//	  if errors.Is(err, pushover.ErrOverQuota) {
+//		     ...
+//	  }
+type APIError struct {
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	ErrorParameters map[string]string
+}
+
+func (ae *APIError) Error() string {
+	text := "Pushover API error " + strconv.Itoa(ae.HTTPStatusCode)
+	if len(ae.Errors) > 0 {
+		text += ": " + strings.Join(ae.Errors, "; ")
+	}
+
+	return text
+}
+
+// Is matches ErrInvalidToken and ErrInvalidUser when the
+// token or user parameter has an error, ErrOverQuota when
+// the HTTP status is 429, and ErrServer when the HTTP status
+// is 500 or above
+func (ae *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidToken:
+		_, ok := ae.ErrorParameters[keyToken]
+		return ok
+	case ErrInvalidUser:
+		_, ok := ae.ErrorParameters[keyUser]
+		return ok
+	case ErrOverQuota:
+		return ae.HTTPStatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return ae.HTTPStatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// ValidationError indicates a request does not meet the
+// constraints documented for the Pushover API. The request
+// was not sent.
+type ValidationError struct {
+	// List of errors, one for each parameter, sorted
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	ErrorParameters map[string]string
+}
+
+func (ve *ValidationError) Error() string {
+	return "Invalid request: " + strings.Join(ve.Errors, "; ")
+}
+
+const defaultBaseURL = "https://api.pushover.net/1"
+
+// Paths of the endpoints relative to the base URL
+const (
+	glancesPath       = "/glances.json"
+	groupsPath        = "/groups"
+	licensesPath      = "/licenses"
+	limitsPath        = "/apps/limits.json"
+	messagesPath      = "/messages.json"
+	receiptsPath      = "/receipts"
+	soundsPath        = "/sounds.json"
+	subscriptionsPath = "/subscriptions/migrate.json"
+	teamsPath         = "/teams"
+	validatePath      = "/users/validate.json"
+)
+
+var messagesURL = defaultBaseURL + messagesPath
+var validateURL = defaultBaseURL + validatePath
+var receiptsURL = defaultBaseURL + receiptsPath
+var soundsURL = defaultBaseURL + soundsPath
+var limitsURL = defaultBaseURL + limitsPath
+var groupsURL = defaultBaseURL + groupsPath
+var glancesURL = defaultBaseURL + glancesPath
+var licensesURL = defaultBaseURL + licensesPath
+var subscriptionsURL = defaultBaseURL + subscriptionsPath
+var teamsURL = defaultBaseURL + teamsPath
+
+// apiResponse is a Pushover API response with the fields
+// common to every response decoded
+type apiResponse struct {
+	*api.Response
+}
+
+// readResponse reads the body of a Pushover API response and
+// decodes the fields present in every response. A body that
+// cannot be read or decoded is reported with
+// ErrInvalidResponse.
+func readResponse(resp *http.Response) (*apiResponse, error) {
+	r, err := api.ReadResponse(resp)
+	if err != nil {
+		return nil, &ErrInvalidResponse{HTTPStatusCode: resp.StatusCode, Err: err}
+	}
+
+	return &apiResponse{Response: r}, nil
+}
+
+// apiError returns an APIError with the errors of the response
+// if the Pushover API rejected the request, otherwise nil
+func (r *apiResponse) apiError(errors []string, errorParameters map[string]string) error {
+	if r.APIStatus == 1 {
+		return nil
+	}
+
+	return &APIError{
+		HTTPStatus:      r.HTTPStatus,
+		HTTPStatusCode:  r.HTTPStatusCode,
+		APIStatus:       r.APIStatus,
+		Request:         r.Request,
+		Errors:          errors,
+		ErrorParameters: errorParameters,
+	}
+}
+
+func headerToInt(key string, h http.Header) (int, bool) {
+	value, err := strconv.Atoi(h.Get(key))
+
+	return value, err == nil
+}
+
+func mapKeyToBool(key string, m map[string]interface{}) (bool, bool) {
+	value, ok := api.MapKeyToInt(key, m)
+
+	return value != 0, ok
+}
+
+// mapKeyToTime converts a Unix timestamp to a time.Time. A
+// timestamp of 0 is translated to the zero time.Time.
+func mapKeyToTime(key string, m map[string]interface{}) (time.Time, bool) {
+	var result time.Time
 
-	if value, ok = m[key].(float64); ok {
-		result = int(value)
+	value, ok := api.MapKeyToInt(key, m)
+	if ok && value != 0 {
+		result = time.Unix(int64(value), 0)
 	}
 
 	return result, ok
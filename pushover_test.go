@@ -0,0 +1,110 @@
+package pushover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func apiErrorServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	switch r.Form.Get("token") {
+	case "overquota":
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprintf(w, `{"errors":["application has exceeded its monthly limit"],"status":0,"request":"%s"}`, id)
+	case "servererror":
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"errors":["internal error"],"status":0,"request":"%s"}`, id)
+	case "unavailable":
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `<html><body>Service Unavailable</body></html>`)
+	case "nostatus":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"request":"%s"}`, id)
+	case "norequest":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"status":1}`)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"%s"}`, id)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(apiErrorServerHandler))
+	defer apiServer.Close()
+
+	request := MessageRequest{PushoverURL: apiServer.URL, Token: "testtoken", User: "invalid", Message: "message"}
+
+	// Invalid user
+	r, e := Message(request)
+	var apiErr *APIError
+	if !errors.As(e, &apiErr) || r == nil || apiErr.HTTPStatusCode != http.StatusBadRequest || apiErr.Request != id ||
+		apiErr.Errors[0] != "user identifier is invalid" || apiErr.ErrorParameters["user"] != "invalid" ||
+		e.Error() != "Pushover API error 400: user identifier is invalid" {
+		t.Error("Invalid user")
+	}
+	if !errors.Is(e, ErrInvalidUser) || errors.Is(e, ErrInvalidToken) || errors.Is(e, ErrOverQuota) || errors.Is(e, ErrServer) ||
+		errors.Is(e, errors.New("user identifier is invalid")) {
+		t.Error("Invalid user matches")
+	}
+
+	// Over quota
+	request.Token = "overquota"
+	_, e = Message(request)
+	if !errors.Is(e, ErrOverQuota) || errors.Is(e, ErrInvalidUser) || errors.Is(e, ErrServer) {
+		t.Error("Over quota")
+	}
+
+	// Server error with a JSON response
+	request.Token = "servererror"
+	_, e = Message(request)
+	if !errors.Is(e, ErrServer) || errors.Is(e, ErrOverQuota) {
+		t.Error("Server error")
+	}
+
+	// Error text without errors
+	if (&APIError{HTTPStatusCode: http.StatusBadRequest}).Error() != "Pushover API error 400" {
+		t.Error("Error text")
+	}
+}
+
+func TestInvalidResponse(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(apiErrorServerHandler))
+	defer apiServer.Close()
+
+	request := SoundsRequest{PushoverURL: apiServer.URL}
+
+	// Server error without a JSON response
+	request.Token = "unavailable"
+	_, e := Sounds(request)
+	var invalid *ErrInvalidResponse
+	var syntaxErr *json.SyntaxError
+	if !errors.As(e, &invalid) || invalid.HTTPStatusCode != http.StatusServiceUnavailable ||
+		!errors.As(e, &syntaxErr) || !errors.Is(e, ErrServer) {
+		t.Error("Server error without JSON")
+	}
+
+	// Missing status
+	request.Token = "nostatus"
+	_, e = Sounds(request)
+	if !errors.As(e, &invalid) || e.Error() != "Invalid response: missing status" || errors.Is(e, ErrServer) {
+		t.Error("Missing status")
+	}
+
+	// Missing request ID
+	request.Token = "norequest"
+	_, e = Sounds(request)
+	if !errors.As(e, &invalid) || e.Error() != "Invalid response: missing request ID" {
+		t.Error("Missing request ID")
+	}
+
+	// Error text without an underlying error
+	if (&ErrInvalidResponse{}).Error() != "Invalid response" {
+		t.Error("Error text")
+	}
+}
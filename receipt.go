@@ -0,0 +1,267 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// minReceiptPollInterval is the shortest interval between
+// receipt requests recommended by Pushover
+var minReceiptPollInterval = 5 * time.Second
+
+// ReceiptRequest is the data for the GET to the Pushover
+// Receipts API. See the Pushover Receipts API documentation
+// for more information on these parameters.
+type ReceiptRequest struct {
+	// The base URL for the Pushover Receipts API. The receipt
+	// is appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Receipt returned in the MessageResponse of a message
+	// sent with a priority of 2
+	Receipt string
+}
+
+// ReceiptResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type ReceiptResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// True if the user has acknowledged the notification
+	Acknowledged bool
+
+	// Time the user acknowledged the notification
+	//
+	// Zero if not acknowledged
+	AcknowledgedAt time.Time
+
+	// User key of the user that first acknowledged the
+	// notification
+	AcknowledgedBy string
+
+	// Device name of the device that first acknowledged
+	// the notification
+	AcknowledgedByDevice string
+
+	// Time the notification was last retried
+	LastDeliveredAt time.Time
+
+	// True if the expiration date has passed
+	Expired bool
+
+	// Time the notification stops being retried
+	ExpiresAt time.Time
+
+	// True if the callback URL has been called
+	CalledBack bool
+
+	// Time the callback URL was called
+	//
+	// Zero if not called back
+	CalledBackAt time.Time
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// ReceiptContext will submit a GET request to the Pushover
+// Receipts API. This function will retrieve the status of a
+// notification sent with a priority of 2.
+//
+//	  resp, err := pushover.ReceiptContext(context.Background(),
+//	    pushover.ReceiptRequest{
+//		     Token:   token,
+//		     Receipt: receipt,
+//	  })
+func ReceiptContext(ctx context.Context, request ReceiptRequest) (*ReceiptResponse, error) {
+	return defaultClient.ReceiptContext(ctx, request)
+}
+
+// ReceiptContext will submit a GET request to the Pushover
+// Receipts API with the client's settings. This function
+// will retrieve the status of a notification sent with a
+// priority of 2.
+//
+//	  resp, err := client.ReceiptContext(context.Background(),
+//	    pushover.ReceiptRequest{
+//		     Receipt: receipt,
+//	  })
+func (c *Client) ReceiptContext(ctx context.Context, request ReceiptRequest) (*ReceiptResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, receiptsURL, receiptsPath)
+	request.Token = c.token(request.Token)
+
+	query := url.Values{
+		keyToken: {request.Token},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+"/"+url.PathEscape(request.Receipt)+".json?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ReceiptResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	var ok bool
+
+	// Populate acknowledgement
+	if r.Acknowledged, ok = mapKeyToBool(keyAcknowledged, a.Result); ok {
+		delete(a.Result, keyAcknowledged)
+	}
+
+	if r.AcknowledgedAt, ok = mapKeyToTime(keyAcknowledgedAt, a.Result); ok {
+		delete(a.Result, keyAcknowledgedAt)
+	}
+
+	if r.AcknowledgedBy, ok = a.Result[keyAcknowledgedBy].(string); ok {
+		delete(a.Result, keyAcknowledgedBy)
+	}
+
+	if r.AcknowledgedByDevice, ok = a.Result[keyAcknowledgedByDevice].(string); ok {
+		delete(a.Result, keyAcknowledgedByDevice)
+	}
+
+	// Populate delivery and expiration
+	if r.LastDeliveredAt, ok = mapKeyToTime(keyLastDeliveredAt, a.Result); ok {
+		delete(a.Result, keyLastDeliveredAt)
+	}
+
+	if r.Expired, ok = mapKeyToBool(keyExpired, a.Result); ok {
+		delete(a.Result, keyExpired)
+	}
+
+	if r.ExpiresAt, ok = mapKeyToTime(keyExpiresAt, a.Result); ok {
+		delete(a.Result, keyExpiresAt)
+	}
+
+	// Populate callback
+	if r.CalledBack, ok = mapKeyToBool(keyCalledBack, a.Result); ok {
+		delete(a.Result, keyCalledBack)
+	}
+
+	if r.CalledBackAt, ok = mapKeyToTime(keyCalledBackAt, a.Result); ok {
+		delete(a.Result, keyCalledBackAt)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// Receipt will submit a GET request to the Pushover
+// Receipts API. This function will retrieve the status of a
+// notification sent with a priority of 2.
+//
+//	  resp, err := pushover.Receipt(pushover.ReceiptRequest{
+//		     Token:   token,
+//		     Receipt: receipt,
+//	  })
+func Receipt(request ReceiptRequest) (*ReceiptResponse, error) {
+	return ReceiptContext(context.Background(), request)
+}
+
+// WaitForAcknowledgement will poll the Pushover Receipts API
+// until the notification sent with a priority of 2 is
+// acknowledged, expires, or the context is done. The final
+// receipt status is returned.
+//
+// Pushover asks that receipts are not polled more than once
+// every 5 seconds. A pollInterval less than this is raised
+// to 5 seconds.
+//
+// If the notification expires before it is acknowledged, the
+// final receipt status is returned with ErrReceiptExpired. If
+// the Pushover API rejects the request, polling stops and the
+// response is returned with an APIError.
+//
+//	resp, err := pushover.WaitForAcknowledgement(context.Background(),
+//	  token, receipt, 30*time.Second)
+func WaitForAcknowledgement(ctx context.Context, token, receipt string, pollInterval time.Duration) (*ReceiptResponse, error) {
+	return defaultClient.WaitForAcknowledgement(ctx, token, receipt, pollInterval)
+}
+
+// WaitForAcknowledgement will poll the Pushover Receipts API
+// with the client's settings until the notification sent with
+// a priority of 2 is acknowledged, expires, or the context is
+// done. If token is empty, the client's Token is used. The
+// client's Timeout applies to each poll rather than the wait.
+//
+//	resp, err := client.WaitForAcknowledgement(context.Background(),
+//	  "", receipt, 30*time.Second)
+func (c *Client) WaitForAcknowledgement(ctx context.Context, token, receipt string, pollInterval time.Duration) (*ReceiptResponse, error) {
+	if pollInterval < minReceiptPollInterval {
+		pollInterval = minReceiptPollInterval
+	}
+
+	request := ReceiptRequest{
+		Token:   token,
+		Receipt: receipt,
+	}
+
+	for {
+		r, err := c.ReceiptContext(ctx, request)
+		if err != nil || r.Acknowledged {
+			return r, err
+		}
+
+		if r.Expired {
+			return r, &ErrReceiptExpired{}
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return r, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+Receipt Valid
+{"status":1,"acknowledged":1,"acknowledged_at":1360019238,"acknowledged_by":"uQiRzpo4DXghDmr9QzzfQu27cmVRsG","acknowledged_by_device":"pixel2xl","last_delivered_at":1360001238,"expired":1,"expires_at":1360019290,"called_back":0,"called_back_at":0,"request":"b1ea5ab8-a4d4-4dbb-a4b5-ca40c9a7dca1"}
+
+Receipt Invalid
+{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"9a3e7ec1-4e48-4a1c-b0c3-6c3d5bd0c88e"}
+*/
+
+var receiptPolls int32
+
+func receiptServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	value := r.Form["token"]
+	if len(value) == 0 || len(value[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	receipt := strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ".json")
+
+	switch receipt {
+	case "":
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"receipt":"not found","errors":["receipt not found; may be invalid or expired"],"status":0,"request":"%s"}`, id)
+	case "failstatus":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":"abc","request":"%s"}`, id)
+	case "failrequest":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":1337}`)
+	case "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"acknowledged":1,"errors":"invalid"],"status":0,"request":"%s"}`, id)
+	case "failbody":
+		w.Header().Set("Content-Length", "1")
+	case "ackafterpoll":
+		if atomic.AddInt32(&receiptPolls, 1) < 3 {
+			fmt.Fprintf(w, `{"status":1,"acknowledged":0,"expired":0,"request":"%s"}`, id)
+		} else {
+			fmt.Fprintf(w, `{"status":1,"acknowledged":1,"acknowledged_at":1360019238,"expired":0,"request":"%s"}`, id)
+		}
+	case "expired":
+		fmt.Fprintf(w, `{"status":1,"acknowledged":0,"acknowledged_at":0,"expired":1,"expires_at":1360019290,"request":"%s"}`, id)
+	case "pending":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"acknowledged":0,"acknowledged_at":0,"acknowledged_by":"","acknowledged_by_device":"","last_delivered_at":1360001238,"expired":0,"expires_at":1360019290,"called_back":0,"called_back_at":0,"request":"%s"}`, id)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"acknowledged":1,"acknowledged_at":1360019238,"acknowledged_by":"user","acknowledged_by_device":"pixel2xl","last_delivered_at":1360001238,"expired":1,"expires_at":1360019290,"called_back":1,"called_back_at":1360019239,"request":"%s"}`, id)
+	}
+}
+
+func TestPushoverReceipt(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(receiptServerHandler))
+	defer apiServer.Close()
+
+	var request ReceiptRequest
+
+	// Default Pushover URL
+	receiptsURL = apiServer.URL
+	r, e := ReceiptContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no receipt
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	r, _ = Receipt(request)
+	if r.HTTPStatusCode != http.StatusNotFound || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "receipt not found; may be invalid or expired" || r.ErrorParameters["receipt"] != "not found" {
+		t.Error("Handling of no receipt")
+	}
+
+	// Acknowledged receipt
+	request.Receipt = "receipt"
+	r, e = Receipt(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		!r.Acknowledged || r.AcknowledgedAt.Unix() != 1360019238 ||
+		r.AcknowledgedBy != "user" || r.AcknowledgedByDevice != "pixel2xl" ||
+		r.LastDeliveredAt.Unix() != 1360001238 || !r.Expired || r.ExpiresAt.Unix() != 1360019290 ||
+		!r.CalledBack || r.CalledBackAt.Unix() != 1360019239 ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Acknowledged receipt")
+	}
+
+	// Pending receipt
+	request.Receipt = "pending"
+	r, e = Receipt(request)
+	if e != nil || r.Acknowledged || !r.AcknowledgedAt.IsZero() || r.Expired || !r.CalledBackAt.IsZero() ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Pending receipt")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = ReceiptContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid API Status in response
+	request.Receipt = "failstatus"
+	_, e = Receipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid API status in response")
+	}
+
+	// Invalid request ID in response
+	request.Receipt = "failrequest"
+	_, e = Receipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid request ID in response")
+	}
+
+	// Invalid json response
+	request.Receipt = "failjson"
+	_, e = Receipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Invalid body
+	request.Receipt = "failbody"
+	_, e = Receipt(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response body")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = Receipt(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestWaitForAcknowledgement(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(receiptServerHandler))
+	defer apiServer.Close()
+
+	receiptsURL = apiServer.URL
+	savedInterval := minReceiptPollInterval
+	minReceiptPollInterval = time.Millisecond
+	defer func() { minReceiptPollInterval = savedInterval }()
+
+	// Acknowledged after polling
+	r, e := WaitForAcknowledgement(context.TODO(), "token", "ackafterpoll", 0)
+	if e != nil || !r.Acknowledged || atomic.LoadInt32(&receiptPolls) != 3 {
+		t.Error("Acknowledged after polling")
+	}
+
+	// Expired without acknowledgement
+	r, e = WaitForAcknowledgement(context.TODO(), "token", "expired", 0)
+	if _, ok := e.(*ErrReceiptExpired); !ok || r == nil || r.Acknowledged || !r.Expired {
+		t.Error("Expired without acknowledgement")
+	}
+
+	// Pushover API error
+	r, e = WaitForAcknowledgement(context.TODO(), "", "pending", 0)
+	if !errors.Is(e, ErrInvalidToken) || r.APIStatus != 0 || r.Errors[0] != "application token is invalid" {
+		t.Error("Pushover API error")
+	}
+
+	// Context cancelled while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	r, e = WaitForAcknowledgement(ctx, "token", "pending", 5*time.Millisecond)
+	if e != context.DeadlineExceeded || (r != nil && r.Acknowledged) {
+		t.Error("Context deadline exceeded while waiting")
+	}
+	cancel()
+
+	// Invalid response
+	_, e = WaitForAcknowledgement(context.TODO(), "token", "failjson", 0)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+}
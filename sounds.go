@@ -0,0 +1,233 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SoundsRequest is the data for the GET to the Pushover
+// Sounds API. See the Pushover API documentation for more
+// information on these parameters.
+type SoundsRequest struct {
+	// The URL for the Pushover REST API GET.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+}
+
+// SoundsResponse is the response from this API. It is read from
+// the body of the Pushover REST API response and translated
+// to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type SoundsResponse struct {
+	// Original response body from GET
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// Map of sound names and their descriptions
+	//
+	// The names are the values accepted in the Sound
+	// field of MessageRequest
+	Sounds map[string]string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// SoundsContext will submit a GET request to the Pushover
+// Sounds API. This function will retrieve the sounds
+// available to the application, including any custom sounds.
+//
+//	  resp, err := pushover.SoundsContext(context.Background(),
+//	    pushover.SoundsRequest{
+//		     Token: token,
+//	  })
+func SoundsContext(ctx context.Context, request SoundsRequest) (*SoundsResponse, error) {
+	return defaultClient.SoundsContext(ctx, request)
+}
+
+// SoundsContext will submit a GET request to the Pushover
+// Sounds API with the client's settings. This function will
+// retrieve the sounds available to the application,
+// including any custom sounds.
+//
+//	resp, err := client.SoundsContext(context.Background(),
+//	  pushover.SoundsRequest{})
+func (c *Client) SoundsContext(ctx context.Context, request SoundsRequest) (*SoundsResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, soundsURL, soundsPath)
+
+	query := url.Values{
+		keyToken: {c.token(request.Token)},
+	}
+
+	resp, err := c.transport().GetURL(ctx, request.PushoverURL+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SoundsResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+		Sounds:         map[string]string{},
+	}
+
+	// Populate sounds
+	if sounds, ok := a.Result[keySounds].(map[string]interface{}); ok {
+		r.Sounds = interfaceMapToStringMap(sounds)
+		delete(a.Result, keySounds)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// Sounds will submit a GET request to the Pushover
+// Sounds API. This function will retrieve the sounds
+// available to the application, including any custom sounds.
+//
+//	  resp, err := pushover.Sounds(pushover.SoundsRequest{
+//		     Token: token,
+//	  })
+func Sounds(request SoundsRequest) (*SoundsResponse, error) {
+	return SoundsContext(context.Background(), request)
+}
+
+type soundsCacheEntry struct {
+	response *SoundsResponse
+	expires  time.Time
+}
+
+// SoundsCache holds responses from the Pushover Sounds API in
+// memory so the sounds for an application token are not
+// retrieved on every request. Only successful responses are
+// cached.
+//
+// A SoundsCache is safe for use by multiple goroutines.
+type SoundsCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]soundsCacheEntry
+}
+
+// DefaultSoundsCache is the SoundsCache used by MessageContext
+// when VerifySound is set in the MessageRequest
+var DefaultSoundsCache = NewSoundsCache(time.Hour)
+
+// NewSoundsCache returns a SoundsCache that keeps responses
+// for the duration ttl
+func NewSoundsCache(ttl time.Duration) *SoundsCache {
+	return &SoundsCache{
+		ttl:     ttl,
+		entries: make(map[string]soundsCacheEntry),
+	}
+}
+
+// SoundsContext returns the cached response for the request's
+// URL and token if it has not expired, otherwise it submits the
+// request to the Pushover Sounds API with SoundsContext. Each
+// call returns its own copy of the response, which may be
+// changed without changing the cache.
+//
+//	  cache := pushover.NewSoundsCache(time.Hour)
+//	  resp, err := cache.SoundsContext(context.Background(),
+//	    pushover.SoundsRequest{
+//		     Token: token,
+//	  })
+func (c *SoundsCache) SoundsContext(ctx context.Context, request SoundsRequest) (*SoundsResponse, error) {
+	return c.soundsContext(ctx, defaultClient, request)
+}
+
+// soundsContext returns the cached response for the request or
+// submits the request with client
+func (c *SoundsCache) soundsContext(ctx context.Context, client *Client, request SoundsRequest) (*SoundsResponse, error) {
+	key := request.PushoverURL + "?" + request.Token
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.response.clone(), nil
+	}
+
+	r, err := client.SoundsContext(ctx, request)
+	if err != nil {
+		return r, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = soundsCacheEntry{response: r.clone(), expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return r, nil
+}
+
+// clone returns a copy of the response so a cached response is
+// not changed by its callers
+func (r *SoundsResponse) clone() *SoundsResponse {
+	clone := *r
+
+	clone.Sounds = make(map[string]string, len(r.Sounds))
+	for k, v := range r.Sounds {
+		clone.Sounds[k] = v
+	}
+
+	clone.Errors = make([]string, len(r.Errors))
+	copy(clone.Errors, r.Errors)
+
+	clone.ErrorParameters = make(map[string]string, len(r.ErrorParameters))
+	for k, v := range r.ErrorParameters {
+		clone.ErrorParameters[k] = v
+	}
+
+	return &clone
+}
+
+// Clear removes all responses from the cache
+func (c *SoundsCache) Clear() {
+	c.mu.Lock()
+	c.entries = make(map[string]soundsCacheEntry)
+	c.mu.Unlock()
+}
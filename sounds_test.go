@@ -0,0 +1,222 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+/*
+Sounds Valid
+{"sounds":{"pushover":"Pushover (default)","bike":"Bike","siren":"Siren","none":"None (silent)"},"status":1,"request":"2f1c3b84-4a4b-4a47-a2c8-0ec7c0f1b5b5"}
+
+Token Invalid
+{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"c2f0d9b4-3e7c-4b8e-9b1a-27b0fe7b8e0e"}
+*/
+
+var soundsRequests int32
+
+func soundsServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	atomic.AddInt32(&soundsRequests, 1)
+
+	// Check token
+	token := r.Form["token"]
+	if len(token) == 0 || len(token[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token[0] == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"sounds":{"bike":"Bike"},"status":1,"request":"%s"`, id)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"sounds":{"pushover":"Pushover (default)","bike":"Bike","siren":"Siren","none":"None (silent)"},"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverSounds(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(soundsServerHandler))
+	defer apiServer.Close()
+
+	var request SoundsRequest
+
+	// Default Pushover URL
+	soundsURL = apiServer.URL
+	r, e := SoundsContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		len(r.Sounds) != 0 || r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Valid submission
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	r, e = Sounds(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Sounds) != 4 || r.Sounds["siren"] != "Siren" || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = SoundsContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = Sounds(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.Get() returning error
+	apiServer.Close()
+	_, e = Sounds(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestSoundsCache(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(soundsServerHandler))
+	defer apiServer.Close()
+
+	cache := NewSoundsCache(time.Hour)
+	request := SoundsRequest{PushoverURL: apiServer.URL, Token: "testtoken"}
+	atomic.StoreInt32(&soundsRequests, 0)
+
+	// First request is retrieved from the API, second from the cache
+	r1, e1 := cache.SoundsContext(context.TODO(), request)
+	r2, e2 := cache.SoundsContext(context.TODO(), request)
+	if e1 != nil || e2 != nil || !reflect.DeepEqual(r1, r2) || atomic.LoadInt32(&soundsRequests) != 1 {
+		t.Error("Cached response")
+	}
+
+	// Changes to a response do not change the cached response
+	r1.Sounds["siren"] = "changed"
+	delete(r2.Sounds, "siren")
+	r3, _ := cache.SoundsContext(context.TODO(), request)
+	if r3 == r2 || r3.Sounds["siren"] != "Siren" || atomic.LoadInt32(&soundsRequests) != 1 {
+		t.Error("Cached response changed")
+	}
+
+	// Cleared cache is retrieved from the API
+	cache.Clear()
+	_, _ = cache.SoundsContext(context.TODO(), request)
+	if atomic.LoadInt32(&soundsRequests) != 2 {
+		t.Error("Cleared cache")
+	}
+
+	// Expired entries are retrieved from the API
+	cache = NewSoundsCache(0)
+	_, _ = cache.SoundsContext(context.TODO(), request)
+	_, _ = cache.SoundsContext(context.TODO(), request)
+	if atomic.LoadInt32(&soundsRequests) != 4 {
+		t.Error("Expired cache")
+	}
+
+	// Failed responses are not cached
+	cache = NewSoundsCache(time.Hour)
+	request.Token = ""
+	_, _ = cache.SoundsContext(context.TODO(), request)
+	r, e := cache.SoundsContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.APIStatus != 0 || atomic.LoadInt32(&soundsRequests) != 6 {
+		t.Error("Failed response cached")
+	}
+
+	// Errors are returned
+	apiServer.Close()
+	request.Token = "testtoken"
+	if _, e = cache.SoundsContext(context.TODO(), request); e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestMessageVerifySound(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(serverHandler))
+	defer apiServer.Close()
+	soundsServer := httptest.NewServer(http.HandlerFunc(soundsServerHandler))
+	defer soundsServer.Close()
+
+	// Stand-in for the Pushover Sounds API, which must not
+	// receive the token of a message sent to another URL
+	var defaultRequests int32
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&defaultRequests, 1)
+		soundsServerHandler(w, r)
+	}))
+	defer defaultServer.Close()
+
+	soundsURL = defaultServer.URL
+	DefaultSoundsCache.Clear()
+
+	request := MessageRequest{
+		PushoverURL: apiServer.URL,
+		Token:       "testtoken",
+		User:        "testuser",
+		Message:     "message",
+		Sound:       "siren",
+		VerifySound: true,
+		SoundsURL:   soundsServer.URL,
+	}
+
+	// Known sound
+	r, e := Message(request)
+	if e != nil || r.APIStatus != 1 {
+		t.Error("Known sound")
+	}
+
+	// Unknown sound
+	request.Sound = "sirene"
+	_, e = Message(request)
+	if us, ok := e.(*ErrUnknownSound); !ok || us.Sound != "sirene" || len(us.Error()) == 0 {
+		t.Error("Unknown sound")
+	}
+
+	// Unknown sound without verification
+	request.VerifySound = false
+	r, e = Message(request)
+	if e != nil || r.APIStatus != 1 {
+		t.Error("Unknown sound without verification")
+	}
+
+	// Rejected without a sounds URL for the message URL
+	request.VerifySound = true
+	request.SoundsURL = ""
+	r, e = Message(request)
+	if su, ok := e.(*ErrSoundsURLRequired); !ok || len(su.Error()) == 0 || r != nil ||
+		atomic.LoadInt32(&defaultRequests) != 0 {
+		t.Error("Message URL without sounds URL")
+	}
+
+	// Sounds request rejected by Pushover
+	request.SoundsURL = soundsServer.URL
+	request.Token = ""
+	r, e = Message(request)
+	if !errors.Is(e, ErrInvalidToken) || r.APIStatus != 0 || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Sounds request rejected")
+	}
+
+	// Sounds request failure
+	soundsServer.Close()
+	request.Token = "failtoken"
+	_, e = Message(request)
+	if e == nil {
+		t.Error("No sounds API server")
+	}
+}
@@ -0,0 +1,171 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+)
+
+// MigrateSubscriptionRequest is the data for the POST to the
+// Pushover Subscriptions API migration. See the Pushover
+// Subscriptions API documentation for more information on these
+// parameters.
+type MigrateSubscriptionRequest struct {
+	// The URL for the Pushover REST API POST.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover API token
+	Token string
+
+	// Subscription code from the subscription's settings page
+	Subscription string
+
+	// The user's key to migrate
+	User string
+
+	// Optional Fields
+
+	// The user's device name that the subscription should be
+	// limited to
+	DeviceName string
+
+	// The user's preferred sound for the subscription
+	Sound string
+}
+
+// MigrateSubscriptionResponse is the response from this API. It is
+// read from the body of the Pushover REST API response and
+// translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type MigrateSubscriptionResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// The subscription user key to use in place of the
+	// user's key when sending messages
+	SubscribedUserKey string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+// MigrateSubscriptionContext will submit a POST request to the
+// Pushover Subscriptions API. This function will migrate a user
+// key to a subscription user key, allowing the user to
+// unsubscribe from the application.
+//
+//	  resp, err := pushover.MigrateSubscriptionContext(context.Background(),
+//	    pushover.MigrateSubscriptionRequest{
+//		     Token:        token,
+//		     Subscription: subscription,
+//		     User:         user,
+//	  })
+func MigrateSubscriptionContext(ctx context.Context, request MigrateSubscriptionRequest) (*MigrateSubscriptionResponse, error) {
+	return defaultClient.MigrateSubscriptionContext(ctx, request)
+}
+
+// MigrateSubscriptionContext will submit a POST request to
+// the Pushover Subscriptions API with the client's settings.
+// This function will migrate a user key to a subscription
+// user key, allowing the user to unsubscribe from the
+// application.
+//
+//	  resp, err := client.MigrateSubscriptionContext(context.Background(),
+//	    pushover.MigrateSubscriptionRequest{
+//		     Subscription: subscription,
+//		     User:         user,
+//	  })
+func (c *Client) MigrateSubscriptionContext(ctx context.Context, request MigrateSubscriptionRequest) (*MigrateSubscriptionResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, subscriptionsURL, subscriptionsPath)
+	request.Token = c.token(request.Token)
+
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: keyToken, value: request.Token},
+		{field: keySubscription, value: request.Subscription},
+		{field: keyUser, value: request.User},
+		{field: keyDeviceName, value: request.DeviceName},
+		{field: keySound, value: request.Sound},
+	}
+
+	formData := url.Values{}
+	for _, v := range fields {
+		if len(v.value) > 0 {
+			formData.Set(v.field, v.value)
+		}
+	}
+
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL, formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MigrateSubscriptionResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate subscription user key
+	var ok bool
+	if r.SubscribedUserKey, ok = a.Result[keySubscribedUserKey].(string); ok {
+		delete(a.Result, keySubscribedUserKey)
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// MigrateSubscription will submit a POST request to the
+// Pushover Subscriptions API. This function will migrate a user
+// key to a subscription user key, allowing the user to
+// unsubscribe from the application.
+//
+//	  resp, err := pushover.MigrateSubscription(pushover.MigrateSubscriptionRequest{
+//		     Token:        token,
+//		     Subscription: subscription,
+//		     User:         user,
+//	  })
+func MigrateSubscription(request MigrateSubscriptionRequest) (*MigrateSubscriptionResponse, error) {
+	return MigrateSubscriptionContext(context.Background(), request)
+}
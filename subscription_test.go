@@ -0,0 +1,119 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+/*
+Migrate Valid
+{"subscribed_user_key":"uz5ebyGbMp9svLgbXA6dAZ2nNAoRDj","status":1,"request":"b0c1d2e3-f4a5-4b6c-8d7e-9f0a1b2c3d4e"}
+
+Migrate Invalid Subscription
+{"subscription":"invalid","errors":["subscription code is invalid"],"status":0,"request":"5f6e7d8c-9b0a-4192-8374-65a4b3c2d1e0"}
+*/
+
+func subscriptionServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	// Check token
+	value := r.Form["token"]
+	if len(value) == 0 || len(value[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check subscription
+	value = r.Form["subscription"]
+	if len(value) == 0 || len(value[0]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"subscription":"invalid","errors":["subscription code is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check user
+	user := r.Form.Get("user")
+	switch user {
+	case "":
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"user":"invalid","errors":["user identifier is invalid"],"status":0,"request":"%s"}`, id)
+	case "failjson":
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"subscribed_user_key":"key","status":1,"request":"%s"`, id)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"subscribed_user_key":"sub-%s-%s-%s","status":1,"request":"%s"}`,
+			user, r.Form.Get("device_name"), r.Form.Get("sound"), id)
+	}
+}
+
+func TestPushoverMigrateSubscription(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(subscriptionServerHandler))
+	defer apiServer.Close()
+
+	var request MigrateSubscriptionRequest
+
+	// Default Pushover URL
+	subscriptionsURL = apiServer.URL
+	r, e := MigrateSubscriptionContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no subscription
+	request.PushoverURL = apiServer.URL
+	request.Token = "testtoken"
+	r, _ = MigrateSubscription(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || len(r.SubscribedUserKey) > 0 ||
+		r.Errors[0] != "subscription code is invalid" || r.ErrorParameters["subscription"] != "invalid" {
+		t.Error("Handling of no subscription")
+	}
+
+	// Handling of no user
+	request.Subscription = "testsubscription"
+	r, _ = MigrateSubscription(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		r.ErrorParameters["user"] != "invalid" {
+		t.Error("Handling of no user")
+	}
+
+	// Load all the fields
+	request.User = "testuser"
+	request.DeviceName = "pixel2xl"
+	request.Sound = "siren"
+	r, e = MigrateSubscription(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		r.SubscribedUserKey != "sub-testuser-pixel2xl-siren" || len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("All fields submitted")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = MigrateSubscriptionContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.User = "failjson"
+	_, e = MigrateSubscription(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = MigrateSubscription(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
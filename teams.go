@@ -0,0 +1,241 @@
+package pushover
+
+import (
+	"context"
+	"net/url"
+)
+
+// AddTeamUserRequest is the data for the POST to the Pushover
+// Teams API to add a user to a team. See the Pushover Teams API
+// documentation for more information on these parameters.
+type AddTeamUserRequest struct {
+	// The base URL for the Pushover Teams API. The add user
+	// path is appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover team API token, found on the team's settings
+	// page
+	Token string
+
+	// The user's email address. If the user does not have a
+	// Pushover account, one is created.
+	Email string
+
+	// Optional Fields
+
+	// The user's name
+	Name string
+
+	// Password for the user's new account. If empty, the user
+	// is sent an email to set one.
+	Password string
+
+	// Send the user's welcome email immediately rather than
+	// waiting for the team's administrator
+	Instant bool
+
+	// Make the user an administrator of the team
+	Admin bool
+
+	// Name of a team delivery group to add the user to
+	Group string
+}
+
+// RemoveTeamUserRequest is the data for the POST to the Pushover
+// Teams API to remove a user from a team. See the Pushover Teams
+// API documentation for more information on these parameters.
+type RemoveTeamUserRequest struct {
+	// The base URL for the Pushover Teams API. The remove user
+	// path is appended to this URL.
+	//
+	// Leave this empty unless you wish to override the URL.
+	PushoverURL string
+
+	// Required fields
+
+	// Pushover team API token, found on the team's settings
+	// page
+	Token string
+
+	// The user's email address
+	Email string
+}
+
+// TeamUpdateResponse is the response from the APIs that change
+// a team. It is read from the body of the Pushover REST API
+// response and translated to this response structure.
+//
+// For access to the original, untranslated response, access
+// the ResponseBody field.
+type TeamUpdateResponse struct {
+	// Original response body from POST
+	ResponseBody string
+
+	// HTTP Status string
+	HTTPStatus string
+
+	// HTTP Status Code
+	HTTPStatusCode int
+
+	// The status as returned by the Pushover API.
+	//
+	// Value of 1 indicates 200 response received.
+	// Any other value indicates an error with the
+	// input.
+	APIStatus int
+
+	// ID assigned to the request by Pushover
+	Request string
+
+	// List of errors returned
+	//
+	// Empty if no errors
+	Errors []string
+
+	// Map of parameters and corresponding errors
+	//
+	// Empty if no errors
+	ErrorParameters map[string]string
+}
+
+func (c *Client) updateTeamContext(ctx context.Context, pushoverURL, action string, formData url.Values) (*TeamUpdateResponse, error) {
+	pushoverURL = c.endpoint(pushoverURL, teamsURL, teamsPath)
+	formData.Set(keyToken, c.token(formData.Get(keyToken)))
+
+	resp, err := c.transport().PostForm(ctx, pushoverURL+"/"+action+".json", formData)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	a, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TeamUpdateResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
+	}
+
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
+
+	return r, a.apiError(r.Errors, r.ErrorParameters)
+}
+
+// AddTeamUserContext will submit a POST request to the Pushover
+// Teams API. This function will add a user to a team by their
+// email address.
+//
+//	  resp, err := pushover.AddTeamUserContext(context.Background(),
+//	    pushover.AddTeamUserRequest{
+//		     Token: token,
+//		     Email: email,
+//		     Name:  name,
+//	  })
+func AddTeamUserContext(ctx context.Context, request AddTeamUserRequest) (*TeamUpdateResponse, error) {
+	return defaultClient.AddTeamUserContext(ctx, request)
+}
+
+// AddTeamUserContext will submit a POST request to the
+// Pushover Teams API with the client's settings. This
+// function will add a user to a team by their email address.
+//
+//	  resp, err := client.AddTeamUserContext(context.Background(),
+//	    pushover.AddTeamUserRequest{
+//		     Email: email,
+//		     Name:  name,
+//	  })
+func (c *Client) AddTeamUserContext(ctx context.Context, request AddTeamUserRequest) (*TeamUpdateResponse, error) {
+	fields := []struct {
+		field string
+		value string
+	}{
+		{field: keyToken, value: request.Token},
+		{field: keyEmail, value: request.Email},
+		{field: keyName, value: request.Name},
+		{field: keyPassword, value: request.Password},
+		{field: keyGroup, value: request.Group},
+	}
+
+	formData := url.Values{}
+	for _, v := range fields {
+		if len(v.value) > 0 {
+			formData.Set(v.field, v.value)
+		}
+	}
+
+	if request.Instant {
+		formData.Set(keyInstant, "true")
+	}
+
+	if request.Admin {
+		formData.Set(keyAdmin, "true")
+	}
+
+	return c.updateTeamContext(ctx, request.PushoverURL, "add_user", formData)
+}
+
+// AddTeamUser will submit a POST request to the Pushover
+// Teams API. This function will add a user to a team by their
+// email address.
+//
+//	  resp, err := pushover.AddTeamUser(pushover.AddTeamUserRequest{
+//		     Token: token,
+//		     Email: email,
+//		     Name:  name,
+//	  })
+func AddTeamUser(request AddTeamUserRequest) (*TeamUpdateResponse, error) {
+	return AddTeamUserContext(context.Background(), request)
+}
+
+// RemoveTeamUserContext will submit a POST request to the Pushover
+// Teams API. This function will remove a user from a team by
+// their email address.
+//
+//	  resp, err := pushover.RemoveTeamUserContext(context.Background(),
+//	    pushover.RemoveTeamUserRequest{
+//		     Token: token,
+//		     Email: email,
+//	  })
+func RemoveTeamUserContext(ctx context.Context, request RemoveTeamUserRequest) (*TeamUpdateResponse, error) {
+	return defaultClient.RemoveTeamUserContext(ctx, request)
+}
+
+// RemoveTeamUserContext will submit a POST request to the
+// Pushover Teams API with the client's settings. This
+// function will remove a user from a team by their email
+// address.
+//
+//	  resp, err := client.RemoveTeamUserContext(context.Background(),
+//	    pushover.RemoveTeamUserRequest{
+//		     Email: email,
+//	  })
+func (c *Client) RemoveTeamUserContext(ctx context.Context, request RemoveTeamUserRequest) (*TeamUpdateResponse, error) {
+	formData := url.Values{
+		keyToken: {request.Token},
+		keyEmail: {request.Email},
+	}
+
+	return c.updateTeamContext(ctx, request.PushoverURL, "remove_user", formData)
+}
+
+// RemoveTeamUser will submit a POST request to the Pushover
+// Teams API. This function will remove a user from a team by
+// their email address.
+//
+//	  resp, err := pushover.RemoveTeamUser(pushover.RemoveTeamUserRequest{
+//		     Token: token,
+//		     Email: email,
+//	  })
+func RemoveTeamUser(request RemoveTeamUserRequest) (*TeamUpdateResponse, error) {
+	return RemoveTeamUserContext(context.Background(), request)
+}
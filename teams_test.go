@@ -0,0 +1,199 @@
+package pushover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*
+Team Update Valid
+{"status":1,"request":"3c1f6a2b-8d4e-4f5a-9b6c-7d8e9f0a1b2c"}
+
+Team Invalid Email
+{"email":"invalid","errors":["email is invalid"],"status":0,"request":"9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"}
+*/
+
+func teamServerHandler(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Check token
+	token := r.Form.Get("token")
+	if len(token) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"token":"invalid","errors":["application token is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	// Check email
+	email := r.Form.Get("email")
+	if !strings.Contains(email, "@") {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"email":"invalid","errors":["email is invalid"],"status":0,"request":"%s"}`, id)
+		return
+	}
+
+	if token == "failjson" {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"status":1,"request":"%s"`, id)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/teams/add_user.json"):
+		// Echo the options in the errors so they can be checked
+		if token == "echo" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"errors":["%s,%s,%s,%s,%s"],"status":0,"request":"%s"}`,
+				r.Form.Get("name"), r.Form.Get("password"), r.Form.Get("instant"),
+				r.Form.Get("admin"), r.Form.Get("group"), id)
+			return
+		}
+	case strings.HasSuffix(r.URL.Path, "/teams/remove_user.json"):
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, `{"status":1,"request":"%s"}`, id)
+}
+
+func TestPushoverAddTeamUser(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(teamServerHandler))
+	defer apiServer.Close()
+
+	var request AddTeamUserRequest
+
+	// Default Pushover URL
+	teamsURL = apiServer.URL + "/teams"
+	r, e := AddTeamUserContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no email
+	request.PushoverURL = apiServer.URL + "/teams"
+	request.Token = "testtoken"
+	r, _ = AddTeamUser(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 ||
+		r.Errors[0] != "email is invalid" || r.ErrorParameters["email"] != "invalid" {
+		t.Error("Handling of no email")
+	}
+
+	// Valid submission
+	request.Email = "user@example.com"
+	r, e = AddTeamUser(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Load all the fields
+	request.Token = "echo"
+	request.Name = "Alice"
+	request.Password = "secret"
+	request.Instant = true
+	request.Admin = true
+	request.Group = "Operations"
+	r, _ = AddTeamUser(request)
+	if r.Errors[0] != "Alice,secret,true,true,Operations" {
+		t.Error("All fields submitted")
+	}
+
+	// Unset options are not submitted
+	request.Instant = false
+	request.Admin = false
+	request.Group = ""
+	r, _ = AddTeamUser(request)
+	if r.Errors[0] != "Alice,secret,,," {
+		t.Error("Unset options submitted")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = AddTeamUserContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = AddTeamUser(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = AddTeamUser(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
+
+func TestPushoverRemoveTeamUser(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(teamServerHandler))
+	defer apiServer.Close()
+
+	var request RemoveTeamUserRequest
+
+	// Default Pushover URL
+	teamsURL = apiServer.URL + "/teams"
+	r, e := RemoveTeamUserContext(context.TODO(), request)
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
+		t.Error("Default Pushover URL")
+	}
+
+	// Handling of no email
+	request.PushoverURL = apiServer.URL + "/teams"
+	request.Token = "testtoken"
+	r, _ = RemoveTeamUser(request)
+	if r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.ErrorParameters["email"] != "invalid" {
+		t.Error("Handling of no email")
+	}
+
+	// Valid submission
+	request.Email = "user@example.com"
+	r, e = RemoveTeamUser(request)
+	if e != nil || r.HTTPStatusCode != http.StatusOK || r.APIStatus != 1 || r.Request != id ||
+		len(r.Errors) > 0 || len(r.ErrorParameters) > 0 {
+		t.Error("Valid submit data")
+	}
+
+	// Context cancellation
+	ctx, cancel := context.WithTimeout(context.Background(), 0*time.Millisecond)
+	_, e = RemoveTeamUserContext(ctx, request)
+	if e != context.DeadlineExceeded {
+		t.Error("Context deadline exceeded")
+	}
+	cancel()
+
+	// Invalid json response
+	request.Token = "failjson"
+	_, e = RemoveTeamUser(request)
+	if _, ok := e.(*ErrInvalidResponse); !ok {
+		t.Error("Invalid response JSON")
+	}
+
+	// Test http.PostForm() returning error
+	apiServer.Close()
+	_, e = RemoveTeamUser(request)
+	if e == nil {
+		t.Error("No API server")
+	}
+}
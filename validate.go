@@ -1,14 +1,10 @@
 package pushover
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 	"net/url"
-	"strings"
 
-	"golang.org/x/net/context/ctxhttp"
+	"github.com/arcanericky/pushover/internal/api"
 )
 
 // ValidateRequest is the data to POST to the Pushover
@@ -89,9 +85,21 @@ type ValidateResponse struct {
 //		     User:    user,
 //	  })
 func ValidateContext(ctx context.Context, request ValidateRequest) (*ValidateResponse, error) {
-	if len(request.PushoverURL) == 0 {
-		request.PushoverURL = validateURL
-	}
+	return defaultClient.ValidateContext(ctx, request)
+}
+
+// ValidateContext will submit a POST request to the Pushover
+// Validate API with the client's settings. This function
+// will check a user or group token to determine if it is
+// valid.
+//
+//	  resp, err := client.ValidateContext(context.Background(),
+//	    pushover.ValidateRequest{
+//		     User: user,
+//	  })
+func (c *Client) ValidateContext(ctx context.Context, request ValidateRequest) (*ValidateResponse, error) {
+	request.PushoverURL = c.endpoint(request.PushoverURL, validateURL, validatePath)
+	request.Token = c.token(request.Token)
 
 	formData := url.Values{
 		keyToken: {request.Token},
@@ -102,71 +110,44 @@ func ValidateContext(ctx context.Context, request ValidateRequest) (*ValidateRes
 		formData.Set(keyDevice, request.Device)
 	}
 
-	resp, err := ctxhttp.PostForm(ctx, &http.Client{}, request.PushoverURL, formData)
+	resp, err := c.transport().PostForm(ctx, request.PushoverURL, formData)
 	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	r := new(ValidateResponse)
-
-	body := &bytes.Buffer{}
-	_, err = body.ReadFrom(resp.Body)
+	a, err := readResponse(resp)
 	if err != nil {
-		return nil, &ErrInvalidResponse{}
+		return nil, err
 	}
 
-	r.ResponseBody = body.String()
-	r.HTTPStatus = resp.Status
-	r.HTTPStatusCode = resp.StatusCode
-
-	// Decode json response
-	var result map[string]interface{}
-	if e := json.NewDecoder(strings.NewReader(string(r.ResponseBody))).Decode(&result); e != nil {
-		return nil, &ErrInvalidResponse{}
+	r := &ValidateResponse{
+		ResponseBody:   a.ResponseBody,
+		HTTPStatus:     a.HTTPStatus,
+		HTTPStatusCode: a.HTTPStatusCode,
+		APIStatus:      a.APIStatus,
+		Request:        a.Request,
 	}
 
 	var ok bool
 
-	// Populate request status
-	if r.APIStatus, ok = mapKeyToInt(keyStatus, result); !ok {
-		return nil, &ErrInvalidResponse{}
-	}
-	delete(result, keyStatus)
-
-	// Populate request ID
-	if r.Request, ok = result[keyRequest].(string); !ok {
-		return nil, &ErrInvalidResponse{}
-	}
-	delete(result, keyRequest)
-
 	// Populate group
-	if r.Group, ok = mapKeyToInt(keyGroup, result); ok {
-		delete(result, keyGroup)
+	if r.Group, ok = api.MapKeyToInt(keyGroup, a.Result); ok {
+		delete(a.Result, keyGroup)
 	}
 
 	// Populate licenses
-	r.Licenses = interfaceArrayToStringArray(keyLicenses, result)
-	delete(result, keyLicenses)
+	r.Licenses = interfaceArrayToStringArray(keyLicenses, a.Result)
+	delete(a.Result, keyLicenses)
 
 	// Populate devices
-	r.Devices = interfaceArrayToStringArray(keyDevices, result)
-	delete(result, keyDevices)
-
-	// Populate errors
-	r.Errors = interfaceArrayToStringArray(keyErrors, result)
-	delete(result, keyErrors)
+	r.Devices = interfaceArrayToStringArray(keyDevices, a.Result)
+	delete(a.Result, keyDevices)
 
-	// Populate parameters with corresponding errors
-	r.ErrorParameters = interfaceMapToStringMap(result)
+	// Populate errors and parameters with corresponding errors
+	r.Errors, r.ErrorParameters = a.ErrorFields()
 
-	return r, nil
+	return r, a.apiError(r.Errors, r.ErrorParameters)
 }
 
 // Validate will submit a POST request to the Pushover
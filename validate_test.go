@@ -2,6 +2,7 @@ package pushover
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -82,7 +83,7 @@ func TestPushoverValidate(t *testing.T) {
 	// Default Pushover URL
 	validateURL = apiServer.URL
 	r, e := ValidateContext(context.TODO(), request)
-	if e != nil || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
+	if !errors.Is(e, ErrInvalidToken) || r.HTTPStatusCode != http.StatusBadRequest || r.APIStatus != 0 || r.Request != id ||
 		r.Errors[0] != "application token is invalid" || r.ErrorParameters["token"] != "invalid" {
 		t.Error("Default Pushover URL")
 	}
@@ -188,4 +189,9 @@ func TestErrorTypes(t *testing.T) {
 	if len(errResponse.Error()) == 0 {
 		t.Error("ErrInvalidResponse does not return string on Error()")
 	}
+
+	errExpired := &ErrReceiptExpired{}
+	if len(errExpired.Error()) == 0 {
+		t.Error("ErrReceiptExpired does not return string on Error()")
+	}
 }